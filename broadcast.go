@@ -0,0 +1,40 @@
+package glcm
+
+import "sync"
+
+// broadcaster wakes up all the waiters on every change,
+// by closing the channel of the current generation and replacing it.
+type broadcaster struct {
+	// mu is a mutex to protect the channel.
+	mu *sync.Mutex
+
+	// ch is closed on the next change.
+	ch chan struct{}
+}
+
+// newBroadcaster returns a new instance of the broadcaster.
+func newBroadcaster() *broadcaster {
+	return &broadcaster{
+		mu: &sync.Mutex{},
+		ch: make(chan struct{}),
+	}
+}
+
+// changed returns a channel which is closed on the next change.
+// It should be taken before checking the state, so that no change is missed.
+func (b *broadcaster) changed() <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.ch
+}
+
+// notify wakes up all the waiters. It never blocks.
+func (b *broadcaster) notify() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	close(b.ch)
+
+	b.ch = make(chan struct{})
+}
@@ -0,0 +1,35 @@
+package glcm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBroadcaster(t *testing.T) {
+	b := newBroadcaster()
+
+	first := b.changed()
+	second := b.changed()
+
+	select {
+	case <-first:
+		t.Fatalf("Expected no change before notify")
+	default:
+	}
+
+	b.notify()
+
+	for _, ch := range []<-chan struct{}{first, second} {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatalf("Expected all the waiters to be woken up")
+		}
+	}
+
+	select {
+	case <-b.changed():
+		t.Fatalf("Expected a new channel after notify")
+	default:
+	}
+}
@@ -0,0 +1,283 @@
+package glcm
+
+import (
+	"sync"
+	"time"
+)
+
+// realClock implements the Clock interface with the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (realClock) Until(t time.Time) time.Duration {
+	return time.Until(t)
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return &realTimer{t: time.NewTimer(d)}
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return &realTicker{t: time.NewTicker(d)}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return &realTimer{t: time.AfterFunc(d, f)}
+}
+
+// realTimer implements the Timer interface with a time.Timer.
+type realTimer struct {
+	t *time.Timer
+}
+
+func (r *realTimer) C() <-chan time.Time {
+	return r.t.C
+}
+
+func (r *realTimer) Stop() bool {
+	return r.t.Stop()
+}
+
+func (r *realTimer) Reset(d time.Duration) bool {
+	return r.t.Reset(d)
+}
+
+// realTicker implements the Ticker interface with a time.Ticker.
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (r *realTicker) C() <-chan time.Time {
+	return r.t.C
+}
+
+func (r *realTicker) Stop() {
+	r.t.Stop()
+}
+
+// FakeClock implements the Clock interface with a time which only moves when it is advanced.
+// It is meant for testing the time based behaviour (backoff, schedules, timeouts) without waiting.
+type FakeClock struct {
+	// mu is a mutex to protect the current time and the timers.
+	mu *sync.Mutex
+
+	// cond is signalled when a timer is added.
+	cond *sync.Cond
+
+	// now is the current time of the clock.
+	now time.Time
+
+	// timers are the active timers and tickers of the clock.
+	timers []*fakeTimer
+}
+
+// NewFakeClock returns a new instance of the fake clock, set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	mu := &sync.Mutex{}
+
+	return &FakeClock{
+		mu:   mu,
+		cond: sync.NewCond(mu),
+		now:  now,
+	}
+}
+
+func (f *FakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+func (f *FakeClock) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+func (f *FakeClock) Until(t time.Time) time.Duration {
+	return t.Sub(f.Now())
+}
+
+func (f *FakeClock) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+func (f *FakeClock) NewTimer(d time.Duration) Timer {
+	return f.add(d, 0, nil)
+}
+
+func (f *FakeClock) NewTicker(d time.Duration) Ticker {
+	return &fakeTicker{f.add(d, d, nil)}
+}
+
+func (f *FakeClock) AfterFunc(d time.Duration, fn func()) Timer {
+	return f.add(d, 0, fn)
+}
+
+// Timers returns the number of the active timers and tickers of the clock.
+func (f *FakeClock) Timers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.timers)
+}
+
+// BlockUntil blocks until the clock has at least the given number of active timers and tickers.
+// It is used to make sure that a goroutine is waiting on the clock, before advancing it.
+func (f *FakeClock) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for len(f.timers) < n {
+		f.cond.Wait()
+	}
+}
+
+// Advance moves the clock forward by the given duration.
+// The timers and tickers due till then are fired in the order of their due time,
+// with the clock set to the due time of each of them.
+func (f *FakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+
+	end := f.now.Add(d)
+
+	for {
+		t := f.earliest()
+		if t == nil || t.at.After(end) {
+			break
+		}
+
+		f.now = t.at
+
+		if t.period > 0 {
+			t.at = t.at.Add(t.period)
+		} else {
+			f.remove(t)
+		}
+
+		// the callbacks are run outside of the lock, as they might use the clock.
+		if t.fn != nil {
+			f.mu.Unlock()
+			t.fn()
+			f.mu.Lock()
+
+			continue
+		}
+
+		// like the time package, a tick is dropped if the previous one is not received yet.
+		select {
+		case t.ch <- f.now:
+		default:
+		}
+	}
+
+	f.now = end
+
+	f.mu.Unlock()
+}
+
+// add adds a new timer (or a ticker, if the period is set) to the clock.
+func (f *FakeClock) add(d, period time.Duration, fn func()) *fakeTimer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	t := &fakeTimer{
+		clock:  f,
+		at:     f.now.Add(d),
+		period: period,
+		ch:     make(chan time.Time, 1),
+		fn:     fn,
+	}
+
+	f.timers = append(f.timers, t)
+	f.cond.Broadcast()
+
+	return t
+}
+
+// earliest returns the active timer which is due first. The lock is expected to be held.
+func (f *FakeClock) earliest() *fakeTimer {
+	var first *fakeTimer
+
+	for _, t := range f.timers {
+		if first == nil || t.at.Before(first.at) {
+			first = t
+		}
+	}
+
+	return first
+}
+
+// remove removes the timer from the active timers. It returns false if the timer was not active.
+// The lock is expected to be held.
+func (f *FakeClock) remove(t *fakeTimer) bool {
+	for i, ft := range f.timers {
+		if ft == t {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// fakeTimer implements the Timer interface for the fake clock. A ticker is a timer with a period.
+type fakeTimer struct {
+	clock *FakeClock
+
+	// at is the time at which the timer is due.
+	at time.Time
+
+	// period is the interval of a ticker. 0 for the timers.
+	period time.Duration
+
+	// ch is the channel on which the time is sent, when the timer is due.
+	ch chan time.Time
+
+	// fn is the function called when the timer is due, instead of sending on the channel.
+	fn func()
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	return t.clock.remove(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	active := t.clock.remove(t)
+
+	t.at = t.clock.now.Add(d)
+	t.clock.timers = append(t.clock.timers, t)
+	t.clock.cond.Broadcast()
+
+	return active
+}
+
+// fakeTicker implements the Ticker interface for the fake clock.
+type fakeTicker struct {
+	*fakeTimer
+}
+
+func (t *fakeTicker) Stop() {
+	t.fakeTimer.Stop()
+}
@@ -0,0 +1,125 @@
+package glcm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClockTimer(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	timer := c.NewTimer(time.Second)
+	after := c.After(time.Second * 2)
+
+	c.Advance(time.Millisecond * 999)
+
+	select {
+	case <-timer.C():
+		t.Fatalf("Expected the timer to not fire before it is due")
+	default:
+	}
+
+	c.Advance(time.Millisecond * 1001)
+
+	select {
+	case at := <-timer.C():
+		assert.Equal(t, start.Add(time.Second), at, "Expected the timer to fire at its due time")
+	default:
+		t.Fatalf("Expected the timer to fire")
+	}
+
+	select {
+	case at := <-after:
+		assert.Equal(t, start.Add(time.Second*2), at, "Expected the after channel to receive its due time")
+	default:
+		t.Fatalf("Expected the after channel to receive")
+	}
+
+	assert.Equal(t, start.Add(time.Second*2), c.Now(), "Expected the clock to be advanced")
+	assert.Zero(t, c.Timers(), "Expected no active timers")
+
+	// a stopped timer does not fire, a reset timer fires at its new due time.
+	timer = c.NewTimer(time.Second)
+	assert.True(t, timer.Stop(), "Expected the active timer to be stopped")
+	assert.False(t, timer.Stop(), "Expected the stopped timer to not be active")
+
+	timer.Reset(time.Second * 2)
+	c.Advance(time.Second)
+
+	select {
+	case <-timer.C():
+		t.Fatalf("Expected the reset timer to not fire before its new due time")
+	default:
+	}
+
+	c.Advance(time.Second)
+
+	select {
+	case <-timer.C():
+	default:
+		t.Fatalf("Expected the reset timer to fire")
+	}
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	c := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	ticker := c.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	ticks := 0
+
+	for i := 0; i < 3; i++ {
+		c.Advance(time.Second)
+
+		select {
+		case <-ticker.C():
+			ticks++
+		default:
+		}
+	}
+
+	assert.Equal(t, 3, ticks, "Expected a tick on every interval")
+
+	// the ticks are dropped, if they are not received.
+	c.Advance(time.Second * 5)
+
+	<-ticker.C()
+
+	select {
+	case <-ticker.C():
+		t.Fatalf("Expected the unreceived ticks to be dropped")
+	default:
+	}
+}
+
+func TestFakeClockAfterFunc(t *testing.T) {
+	c := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	var order []int
+
+	c.AfterFunc(time.Second*2, func() { order = append(order, 2) })
+	c.AfterFunc(time.Second, func() { order = append(order, 1) })
+
+	done := make(chan struct{})
+
+	go func() {
+		c.BlockUntil(3)
+		close(done)
+	}()
+
+	c.AfterFunc(time.Second*3, func() { order = append(order, 3) })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected BlockUntil to return once the timers are added")
+	}
+
+	c.Advance(time.Second * 3)
+
+	assert.Equal(t, []int{1, 2, 3}, order, "Expected the functions to be called in the order of their due time")
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/achu-1612/glcm"
 )
@@ -22,6 +24,113 @@ func Printf(r *glcm.SocketResponse) {
 	}
 }
 
+// PrintResults prints the outcome of an operation for each of the services.
+// The response of the older runners, without the outcome for each service, is printed as is.
+func PrintResults(r *glcm.SocketResponse) {
+	res := make(map[string]glcm.ServiceOutcome)
+
+	b, err := json.Marshal(r.Result)
+	if err != nil {
+		Fatalf("Unable to marshal data, error: %v", err)
+	}
+
+	if err := json.Unmarshal(b, &res); err != nil {
+		Printf(r)
+
+		return
+	}
+
+	names := make([]string, 0, len(res))
+	for name := range res {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if res[name].Status == glcm.Success {
+			Successf("%s: %s\n", name, res[name].Result)
+		} else {
+			Errorf("%s: %s\n", name, res[name].Result)
+		}
+	}
+}
+
+// PrintMatches prints the names of the services matched by a command, one per line.
+func PrintMatches(r *glcm.SocketResponse) {
+	var names []string
+
+	b, err := json.Marshal(r.Result)
+	if err != nil {
+		Fatalf("Unable to marshal data, error: %v", err)
+	}
+
+	// the failures (e.g. a selector without match) are reported as a message.
+	if r.Status != glcm.Success || json.Unmarshal(b, &names) != nil {
+		Printf(r)
+
+		return
+	}
+
+	for _, name := range names {
+		fmt.Fprintln(Emitter, name)
+	}
+}
+
+// PrintOperation prints the asynchronous operation along with the outcome for each of its services.
+func PrintOperation(r *glcm.SocketResponse) {
+	op := &glcm.Operation{}
+
+	b, err := json.Marshal(r.Result)
+	if err != nil {
+		Fatalf("Unable to marshal data, error: %v", err)
+	}
+
+	// the failures (e.g. an unknown operation) are reported as a message.
+	if err := json.Unmarshal(b, op); err != nil || op.ID == "" {
+		Printf(r)
+
+		return
+	}
+
+	fmt.Fprintf(Emitter, "ID:       %s\n", op.ID)
+	fmt.Fprintf(Emitter, "Action:   %s %s\n", op.Action, strings.Join(op.Services, ","))
+	fmt.Fprintf(Emitter, "State:    %s\n", op.State)
+	fmt.Fprintf(Emitter, "Started:  %s\n", formatTime(&op.StartTime))
+	fmt.Fprintf(Emitter, "Finished: %s\n", formatTime(&op.EndTime))
+
+	PrintResults(&glcm.SocketResponse{Result: op.Results, Status: r.Status})
+}
+
+// PrintHealth prints the overall health of the runner along with the reasons for it.
+func PrintHealth(r *glcm.SocketResponse) {
+	h := &glcm.Health{}
+
+	b, err := json.Marshal(r.Result)
+	if err != nil {
+		Fatalf("Unable to marshal data, error: %v", err)
+	}
+
+	if err := json.Unmarshal(b, h); err != nil || h.State == "" {
+		Printf(r)
+
+		return
+	}
+
+	switch h.State {
+	case glcm.HealthHealthy:
+		Successf("%s\n", h.State)
+	case glcm.HealthDegraded:
+		fmt.Fprintf(Emitter, "\033[33m%s\033[0m\n", h.State)
+	default:
+		Errorf("%s\n", h.State)
+	}
+
+	for _, reason := range h.Reasons {
+		fmt.Fprintf(Emitter, "  - %s\n", reason)
+	}
+}
+
 // Errorf prints default text to std out.
 func Errorf(format string, a ...interface{}) {
 	msg := fmt.Sprintf("\033[31m"+format+"\033[0m", a...)
@@ -41,15 +150,45 @@ func Fatalf(format string, a ...interface{}) {
 	os.Exit(1)
 }
 
-// PrintStatus prints list of service status in tabular format.
-func PrintStatus(item *glcm.SocketResponse) {
+// formatTime formats the given time for the tabular output.
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
 
-	out := new(tabwriter.Writer)
-	out.Init(Emitter, 0, 8, 1, '\t', 0)
+	return t.Local().Format(time.RFC3339)
+}
 
-	cols := strings.Split("Name,Status,Uptime,Restarts", ",")
-	_, _ = fmt.Fprintln(out, strings.ToUpper(strings.Join(cols, "\t")))
+// formatReload formats the outcome of the last reload of a service.
+func formatReload(r *glcm.ReloadResult) string {
+	switch {
+	case r == nil:
+		return "-"
+	case r.Restarted && r.Error != "":
+		return "restarted (" + r.Error + ")"
+	case r.Error != "":
+		return "failed (" + r.Error + ")"
+	default:
+		return "ok " + formatTime(&r.Time)
+	}
+}
+
+// formatDuration formats the given duration for the tabular output.
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%02dh:%02dm:%02ds", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+// formatText formats the given text for the tabular output.
+func formatText(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
 
+// runnerStatus decodes the runner status from the response.
+func runnerStatus(item *glcm.SocketResponse) *glcm.RunnerStatus {
 	data := &glcm.RunnerStatus{}
 
 	b, err := json.Marshal(item.Result)
@@ -61,23 +200,163 @@ func PrintStatus(item *glcm.SocketResponse) {
 		Fatalf("Unable to unmarshal data, error: %v", err)
 	}
 
+	return data
+}
+
+// PrintStatus prints list of service status in tabular format.
+// The wide format adds the run statistics of the services.
+func PrintStatus(item *glcm.SocketResponse, wide bool) {
+
+	out := new(tabwriter.Writer)
+	out.Init(Emitter, 0, 8, 1, '\t', 0)
+
+	cols := strings.Split("Name,Status,Uptime,Restarts,Next Transition,Last Reload", ",")
+	if wide {
+		cols = append(cols, strings.Split("Starts,Stops,Last Exit,Total Uptime,Availability,Last Error", ",")...)
+	}
+
+	_, _ = fmt.Fprintln(out, strings.ToUpper(strings.Join(cols, "\t")))
+
+	data := runnerStatus(item)
+
 	for name, info := range data.Services {
 		var f []string
 		f = append(
 			f,
 			name,
 			string(info.Status),
-			fmt.Sprintf("%02dh:%02dm:%02ds", int(info.Uptime.Hours()), int(info.Uptime.Minutes())%60, int(info.Uptime.Seconds())%60),
+			formatDuration(info.Uptime),
 			fmt.Sprintf("%d", info.Restarts),
+			formatTime(info.NextTransition),
+			formatReload(info.Reload),
 		)
 
+		if wide {
+			f = append(
+				f,
+				fmt.Sprintf("%d", info.Starts),
+				fmt.Sprintf("%d", info.Stops),
+				formatTime(info.LastExit),
+				formatDuration(info.TotalUptime),
+				fmt.Sprintf("%.2f%%", info.Availability),
+				formatText(info.LastError),
+			)
+		}
+
 		_, _ = fmt.Fprintln(out, strings.Join(f, "\t"))
 	}
 	_ = out.Flush()
 
 	fmt.Println()
 
-	if err != nil {
-		Fatalf("Unable to print table, error: %v", err)
+	if data.Health != nil {
+		fmt.Fprintf(Emitter, "Health: %s\n", data.Health.State)
+	}
+}
+
+// PrintDescribe prints all the available information of the given service.
+func PrintDescribe(item *glcm.SocketResponse, name string) {
+	data := runnerStatus(item)
+
+	info, ok := data.Services[name]
+	if !ok {
+		Fatalf("service %s is not found\n", name)
+	}
+
+	out := new(tabwriter.Writer)
+	out.Init(Emitter, 0, 8, 1, '\t', 0)
+
+	rows := [][2]string{
+		{"Name", name},
+		{"Description", formatText(info.Description)},
+		{"Owner", formatText(info.Owner)},
+		{"Version", formatText(info.Version)},
+		{"Labels", formatLabels(info.Labels)},
+		{"Status", string(info.Status)},
+		{"Critical", fmt.Sprintf("%t", info.Critical)},
+		{"Ready", fmt.Sprintf("%t", info.Ready)},
+		{"Uptime", formatDuration(info.Uptime)},
+		{"Restarts", fmt.Sprintf("%d", info.Restarts)},
+		{"Starts", fmt.Sprintf("%d", info.Starts)},
+		{"Stops", fmt.Sprintf("%d", info.Stops)},
+		{"Last Start", formatTime(info.LastStart)},
+		{"Last Exit", formatTime(info.LastExit)},
+		{"Last Exit Reason", formatText(info.LastExitReason)},
+		{"Last Error", formatText(info.LastError)},
+		{"Total Uptime", formatDuration(info.TotalUptime)},
+		{"Downtime", formatDuration(info.Downtime)},
+		{"Availability", fmt.Sprintf("%.2f%%", info.Availability)},
+		{"Next Transition", formatTime(info.NextTransition)},
+		{"Last Reload", formatReload(info.Reload)},
+	}
+
+	if info.Check != nil {
+		rows = append(rows, [2]string{"Last Health Check", formatHealthCheck(info.Check)})
+	}
+
+	for _, row := range rows {
+		_, _ = fmt.Fprintf(out, "%s:\t%s\n", row[0], row[1])
 	}
+
+	_ = out.Flush()
+
+	if len(info.Details) > 0 {
+		keys := make([]string, 0, len(info.Details))
+		for k := range info.Details {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		fmt.Fprintf(Emitter, "\nDetails:\n")
+
+		out.Init(Emitter, 0, 8, 1, '\t', 0)
+
+		for _, k := range keys {
+			_, _ = fmt.Fprintf(out, "  %s:\t%v\n", k, info.Details[k])
+		}
+
+		_ = out.Flush()
+	}
+
+	if len(info.History) == 0 {
+		return
+	}
+
+	fmt.Fprintf(Emitter, "\nHistory:\n")
+
+	out.Init(Emitter, 0, 8, 1, '\t', 0)
+
+	_, _ = fmt.Fprintln(out, "  TIME\tFROM\tTO\tREASON")
+
+	for _, t := range info.History {
+		_, _ = fmt.Fprintf(out, "  %s\t%s\t%s\t%s\n", formatTime(&t.Time), t.From, t.To, t.Reason)
+	}
+
+	_ = out.Flush()
+}
+
+// formatLabels formats the labels of a service, sorted by their keys.
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// formatHealthCheck formats the outcome of the last health check of a service.
+func formatHealthCheck(c *glcm.HealthCheckResult) string {
+	if c.Error != "" {
+		return "failed (" + c.Error + ") " + formatTime(&c.Time)
+	}
+
+	return "ok " + formatTime(&c.Time)
 }
@@ -37,11 +37,10 @@ func runnerCommands() []cli.Command {
 			Usage: "stop given list of sevices",
 			Flags: []cli.Flag{
 				getSocketFlag(),
-				cli.StringFlag{
-					Name:     "services",
-					Usage:    "List of services to stop",
-					Required: true,
-				},
+				getServicesFlag("stop", false),
+				getSelectorFlag(),
+				getAsyncFlag(),
+				getDryRunFlag(),
 			},
 			Action: stopAction,
 		},
@@ -56,22 +55,76 @@ func runnerCommands() []cli.Command {
 			Usage: "Restart given list of sevices",
 			Flags: []cli.Flag{
 				getSocketFlag(),
-				cli.StringFlag{
-					Name:     "services",
-					Usage:    "List of services to stop",
-					Required: true,
-				},
+				getServicesFlag("restart", false),
+				getSelectorFlag(),
+				getAsyncFlag(),
+				getDryRunFlag(),
 			},
 			Action: restartAction,
 		},
+		{
+			Name:  "reload",
+			Usage: "Reload given list of services",
+			Flags: []cli.Flag{
+				getSocketFlag(),
+				getServicesFlag("reload", true),
+			},
+			Action: reloadAction,
+		},
 		{
 			Name:  "status",
 			Usage: "Get the status of the runner and services",
 			Flags: []cli.Flag{
 				getSocketFlag(),
+				getServicesFlag("show", false),
+				getSelectorFlag(),
+				cli.BoolFlag{
+					Name:  "wide",
+					Usage: "Show the run statistics of the services",
+				},
 			},
 			Action: statusAction,
 		},
+		{
+			Name:      "describe",
+			Usage:     "Show all the available information of a service",
+			ArgsUsage: "<service>",
+			Flags:     []cli.Flag{getSocketFlag()},
+			Action:    describeAction,
+		},
+		{
+			Name:   "health",
+			Usage:  "Get the health of the runner. Exits with a non-zero code when the runner is unhealthy",
+			Flags:  []cli.Flag{getSocketFlag()},
+			Action: healthAction,
+		},
+		{
+			Name:      "signal",
+			Usage:     "Deliver a signal to a service, which has subscribed to it",
+			ArgsUsage: "<service> <signal>",
+			Flags:     []cli.Flag{getSocketFlag()},
+			Action:    signalAction,
+		},
+		{
+			Name:  "op",
+			Usage: "Follow an asynchronous operation",
+			Subcommands: []cli.Command{
+				{
+					Name:      "status",
+					Usage:     "Get the status of the operation",
+					ArgsUsage: "<id>",
+					Flags:     []cli.Flag{getSocketFlag()},
+					Action:    opStatusAction,
+				},
+				{
+					Name:      "wait",
+					Usage:     "Wait for the operation to finish",
+					ArgsUsage: "<id>",
+					Flags:     []cli.Flag{getSocketFlag()},
+					Action:    opWaitAction,
+				},
+			},
+		},
 	}
 }
 
@@ -85,13 +138,89 @@ func getSocketFlag() cli.Flag {
 
 }
 
-// validateServiceNameList validates the service name list.
-func validateServiceNameList(s string) error {
-	if len(strings.Split(s, ",")) == 0 {
-		return fmt.Errorf("service name list cannot be empty")
+// getSelectorFlag returns the flag to select the services by their labels.
+func getSelectorFlag() cli.Flag {
+	return cli.StringFlag{
+		Name:  "selector, l",
+		Usage: "Label selector of the services, e.g. tier=ingest,env!=canary",
+	}
+}
+
+// getDryRunFlag returns the flag to list the services matched by the command, without acting on them.
+func getDryRunFlag() cli.Flag {
+	return cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "List the services matched by the names, patterns or selector, without acting on them",
+	}
+}
+
+// getAsyncFlag returns the flag to submit the command as an asynchronous operation.
+func getAsyncFlag() cli.Flag {
+	return cli.BoolFlag{
+		Name:  "async",
+		Usage: "Submit the command as an asynchronous operation and print its id",
+	}
+}
+
+// getServicesFlag returns the repeatable flag for the services of a command.
+func getServicesFlag(verb string, required bool) cli.Flag {
+	return cli.StringSliceFlag{
+		Name: "services",
+		Usage: "Services to " + verb + ", as names, glob patterns (ingest.*) or regular expressions (re:^ingest\\.[0-9]{1,3}$). " +
+			"Repeat the flag or separate the names and the glob patterns by commas. A regular expression is taken as is, commas included",
+		Required: required,
+	}
+}
+
+// parseServiceNameList parses the service names given with the repeatable services flag.
+// The values are split by commas, except the regular expressions which may have commas (e.g. {1,3}).
+func parseServiceNameList(values []string) ([]string, error) {
+	var names []string
+
+	for _, v := range values {
+		parts := strings.Split(v, ",")
+		if strings.HasPrefix(strings.TrimSpace(v), "re:") {
+			parts = []string{v}
+		}
+
+		for _, n := range parts {
+			if n = strings.TrimSpace(n); n != "" {
+				names = append(names, n)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("service name list cannot be empty")
+	}
+
+	return names, nil
+}
+
+// parseTargets returns the targets of a command, i.e. the given service names along with the label selector.
+// The selector is resolved to the services by the runner.
+func parseTargets(c *cli.Context) ([]string, error) {
+	var targets []string
+
+	if len(c.StringSlice("services")) > 0 {
+		names, err := parseServiceNameList(c.StringSlice("services"))
+		if err != nil {
+			return nil, err
+		}
+
+		targets = append(targets, names...)
+	}
+
+	// the socket expects the targets separated by spaces.
+	if selector := strings.ReplaceAll(c.String("selector"), " ", ""); selector != "" {
+		targets = append(targets, selector)
 	}
 
-	return nil
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("either the services or the selector is required")
+	}
+
+	return targets, nil
 }
 
 // sendMessageOnSocket sends the message on the socket and returns the response.
@@ -131,21 +260,55 @@ func stopAllAction(c *cli.Context) {
 
 // stopAction stops the given list of services.
 func stopAction(c *cli.Context) {
-	services := c.String("services")
-
-	if err := validateServiceNameList(services); err != nil {
+	services, err := parseTargets(c)
+	if err != nil {
 		display.Fatalf("validate service name list: %v", err)
 	}
 
+	if c.Bool("dry-run") {
+		matchAction(c, services)
+
+		return
+	}
+
+	action := glcm.SocketActionStopService
+	if c.Bool("async") {
+		action = glcm.SocketActionStopServiceAsync
+	}
+
+	// the socket expects the service names separated by spaces.
 	res, err := sendMessageOnSocket(
 		c.String("socket"),
-		fmt.Sprintf("%s %s\n", glcm.SocketActionStopService, services),
+		fmt.Sprintf("%s %s\n", action, strings.Join(services, " ")),
 	)
 	if err != nil {
 		display.Fatalf("stop given service(s): %v", err)
 	}
 
-	display.Printf(res)
+	if c.Bool("async") {
+		display.Printf(res)
+
+		return
+	}
+
+	display.PrintResults(res)
+}
+
+// matchAction lists the services matched by the given targets, without acting on them.
+func matchAction(c *cli.Context, targets []string) {
+	res, err := sendMessageOnSocket(
+		c.String("socket"),
+		fmt.Sprintf("%s %s\n", glcm.SocketActionMatch, strings.Join(targets, " ")),
+	)
+	if err != nil {
+		display.Fatalf("match given service(s): %v", err)
+	}
+
+	display.PrintMatches(res)
+
+	if res.Status != glcm.Success {
+		os.Exit(1)
+	}
 }
 
 // restartAllAction restarts all the services.
@@ -163,32 +326,170 @@ func restartAllAction(c *cli.Context) {
 
 // restartAction restarts the given list of services.
 func restartAction(c *cli.Context) {
-	services := c.String("services")
-
-	if err := validateServiceNameList(services); err != nil {
+	services, err := parseTargets(c)
+	if err != nil {
 		display.Fatalf("validate service name list: %v", err)
 	}
 
+	if c.Bool("dry-run") {
+		matchAction(c, services)
+
+		return
+	}
+
+	action := glcm.SocketActionRestartService
+	if c.Bool("async") {
+		action = glcm.SocketActionRestartServiceAsync
+	}
+
+	// the socket expects the service names separated by spaces.
 	res, err := sendMessageOnSocket(
 		c.String("socket"),
-		fmt.Sprintf("%s %s\n", glcm.SocketActionRestartService, services),
+		fmt.Sprintf("%s %s\n", action, strings.Join(services, " ")),
 	)
 	if err != nil {
 		display.Fatalf("restart given service(s): %v", err)
 	}
 
+	if c.Bool("async") {
+		display.Printf(res)
+
+		return
+	}
+
+	display.PrintResults(res)
+}
+
+// reloadAction reloads the given list of services.
+func reloadAction(c *cli.Context) {
+	services, err := parseServiceNameList(c.StringSlice("services"))
+	if err != nil {
+		display.Fatalf("validate service name list: %v", err)
+	}
+
+	// the socket expects the service names separated by spaces.
+	res, err := sendMessageOnSocket(
+		c.String("socket"),
+		fmt.Sprintf("%s %s\n", glcm.SocketActionReloadService, strings.Join(services, " ")),
+	)
+	if err != nil {
+		display.Fatalf("reload given service(s): %v", err)
+	}
+
+	display.PrintResults(res)
+}
+
+// signalAction delivers a signal to the given service.
+func signalAction(c *cli.Context) {
+	if c.NArg() != 2 {
+		display.Fatalf("service and signal are required, e.g. glcm signal MyService USR1\n")
+	}
+
+	res, err := sendMessageOnSocket(
+		c.String("socket"),
+		fmt.Sprintf("%s %s %s\n", glcm.SocketActionSignalService, c.Args().Get(0), c.Args().Get(1)),
+	)
+	if err != nil {
+		display.Fatalf("signal given service: %v", err)
+	}
+
 	display.Printf(res)
+
+	if res.Status != glcm.Success {
+		os.Exit(1)
+	}
 }
 
 // statusAction gets the status of the runner and services.
+// The services can be limited by their names or a label selector.
 func statusAction(c *cli.Context) {
+	msg := fmt.Sprintf("%s\n", glcm.SocketActionStatus)
+
+	if len(c.StringSlice("services")) > 0 || c.String("selector") != "" {
+		targets, err := parseTargets(c)
+		if err != nil {
+			display.Fatalf("validate service name list: %v", err)
+		}
+
+		msg = fmt.Sprintf("%s %s\n", glcm.SocketActionStatus, strings.Join(targets, " "))
+	}
+
+	res, err := sendMessageOnSocket(c.String("socket"), msg)
+	if err != nil {
+		display.Fatalf("stop all services: %v", err)
+	}
+
+	if res.Status != glcm.Success {
+		display.Printf(res)
+		os.Exit(1)
+	}
+
+	display.PrintStatus(res, c.Bool("wide"))
+}
+
+// describeAction shows all the available information of the given service.
+func describeAction(c *cli.Context) {
+	name := strings.TrimSpace(c.Args().First())
+	if name == "" {
+		display.Fatalf("service name is required\n")
+	}
+
 	res, err := sendMessageOnSocket(
 		c.String("socket"),
 		fmt.Sprintf("%s\n", glcm.SocketActionStatus),
 	)
 	if err != nil {
-		display.Fatalf("stop all services: %v", err)
+		display.Fatalf("describe service: %v", err)
 	}
 
-	display.PrintStatus(res)
+	display.PrintDescribe(res, name)
+}
+
+// healthAction gets the health of the runner. It exits with 1, when the runner is unhealthy.
+func healthAction(c *cli.Context) {
+	res, err := sendMessageOnSocket(
+		c.String("socket"),
+		fmt.Sprintf("%s\n", glcm.SocketActionHealth),
+	)
+	if err != nil {
+		display.Fatalf("get health: %v", err)
+	}
+
+	display.PrintHealth(res)
+
+	if res.Status != glcm.Success {
+		os.Exit(1)
+	}
+}
+
+// opStatusAction gets the status of the given operation.
+func opStatusAction(c *cli.Context) {
+	opAction(c, glcm.SocketOperationStatus)
+}
+
+// opWaitAction waits for the given operation to finish.
+func opWaitAction(c *cli.Context) {
+	opAction(c, glcm.SocketOperationWait)
+}
+
+// opAction sends the operation sub-command for the operation id given as the argument.
+func opAction(c *cli.Context, command string) {
+	id := strings.TrimSpace(c.Args().First())
+	if id == "" {
+		display.Fatalf("operation id is required\n")
+	}
+
+	res, err := sendMessageOnSocket(
+		c.String("socket"),
+		fmt.Sprintf("%s %s %s\n", glcm.SocketActionOperation, command, id),
+	)
+	if err != nil {
+		display.Fatalf("%s operation: %v", command, err)
+	}
+
+	display.PrintOperation(res)
+
+	if res.Status != glcm.Success {
+		os.Exit(1)
+	}
 }
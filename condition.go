@@ -0,0 +1,68 @@
+package glcm
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// condition implements the Condition interface.
+type condition struct {
+	f    func() error
+	name string
+}
+
+// NewCondition returns a new instance of the Condition.
+// The condition is met when the given function returns nil.
+func NewCondition(name string, f func() error) Condition {
+	return &condition{
+		f:    f,
+		name: name,
+	}
+}
+
+// Check evaluates the condition.
+func (c *condition) Check() error {
+	return c.f()
+}
+
+// Name returns the name of the condition.
+func (c *condition) Name() string {
+	return c.name
+}
+
+// EnvSetCondition returns a condition which is met when the given environment variable is set.
+func EnvSetCondition(key string) Condition {
+	return NewCondition("env-set:"+key, func() error {
+		if _, ok := os.LookupEnv(key); !ok {
+			return fmt.Errorf("environment variable %s is not set", key)
+		}
+
+		return nil
+	})
+}
+
+// FileExistsCondition returns a condition which is met when the given file exists.
+func FileExistsCondition(path string) Condition {
+	return NewCondition("file-exists:"+path, func() error {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("file %s does not exist: %w", path, err)
+		}
+
+		return nil
+	})
+}
+
+// PortReachableCondition returns a condition which is met when a connection can be
+// established to the given address with in the timeout.
+func PortReachableCondition(network, address string, timeout time.Duration) Condition {
+	return NewCondition("port-reachable:"+address, func() error {
+		conn, err := net.DialTimeout(network, address, timeout)
+		if err != nil {
+			return fmt.Errorf("address %s is not reachable: %w", address, err)
+		}
+
+		return conn.Close()
+	})
+}
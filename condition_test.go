@@ -0,0 +1,54 @@
+package glcm
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCondition(t *testing.T) {
+	c := NewCondition("always", func() error { return nil })
+	assert.Nil(t, c.Check(), "Expected condition to be met")
+	assert.Equal(t, "always", c.Name(), "Expected condition name to match")
+
+	c = NewCondition("never", func() error { return errors.New("never") })
+	assert.NotNil(t, c.Check(), "Expected condition to not be met")
+}
+
+func TestEnvSetCondition(t *testing.T) {
+	c := EnvSetCondition("GLCM_TEST_CONDITION")
+	assert.NotNil(t, c.Check(), "Expected condition to not be met for unset env")
+
+	t.Setenv("GLCM_TEST_CONDITION", "")
+	assert.Nil(t, c.Check(), "Expected condition to be met for set env")
+}
+
+func TestFileExistsCondition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flag")
+
+	c := FileExistsCondition(path)
+	assert.NotNil(t, c.Check(), "Expected condition to not be met for missing file")
+
+	assert.Nil(t, os.WriteFile(path, nil, 0600))
+	assert.Nil(t, c.Check(), "Expected condition to be met for existing file")
+}
+
+func TestPortReachableCondition(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	addr := l.Addr().String()
+
+	c := PortReachableCondition("tcp", addr, time.Second)
+	assert.Nil(t, c.Check(), "Expected condition to be met for listening port")
+
+	l.Close()
+	assert.NotNil(t, c.Check(), "Expected condition to not be met for closed port")
+}
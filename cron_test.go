@@ -0,0 +1,102 @@
+package glcm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{name: "Every minute", expr: "* * * * *"},
+		{name: "Lists, ranges and steps", expr: "*/15 1-5 1,15 */2 1-5"},
+		{name: "Step from value", expr: "5/20 * * * *"},
+		{name: "Descriptor", expr: "@daily"},
+		{name: "Too few fields", expr: "* * * *", wantErr: true},
+		{name: "Out of range", expr: "60 * * * *", wantErr: true},
+		{name: "Invalid range", expr: "* 5-1 * * *", wantErr: true},
+		{name: "Invalid step", expr: "*/0 * * * *", wantErr: true},
+		{name: "Invalid value", expr: "a * * * *", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCron(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseCron() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// 2024-01-01 is a Monday.
+	monday := time.Date(2024, 1, 1, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "Every minute",
+			expr: "* * * * *",
+			from: monday,
+			want: time.Date(2024, 1, 1, 10, 8, 0, 0, time.UTC),
+		},
+		{
+			name: "Every 15 minutes",
+			expr: "*/15 * * * *",
+			from: monday,
+			want: time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			name: "Daily at 1 AM",
+			expr: "0 1 * * *",
+			from: monday,
+			want: time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Weekdays at 1 AM from friday",
+			expr: "0 1 * * 1-5",
+			from: monday.AddDate(0, 0, 4),
+			want: time.Date(2024, 1, 8, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Day of month or day of week",
+			expr: "0 0 15 * 0",
+			from: monday,
+			want: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Yearly",
+			expr: "@yearly",
+			from: monday,
+			want: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Never matching",
+			expr: "0 0 31 2 *",
+			from: monday,
+			want: time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := parseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("parseCron() error = %v", err)
+			}
+
+			got := c.next(tt.from)
+			assert.True(t, tt.want.Equal(got), "Expected next %v, got %v", tt.want, got)
+		})
+	}
+}
@@ -3,14 +3,27 @@ package glcm
 import "errors"
 
 var (
-	ErrServiceNotRunning = errors.New("service not running")
+	ErrServiceNotFound     = errors.New("service not found")
+	ErrServiceNotRunning   = errors.New("service not running")
+	ErrInvalidTransition   = errors.New("invalid status transition")
+	ErrOperationTimeout    = errors.New("operation timed out")
+	ErrOperationNotFound   = errors.New("operation not found")
+	ErrReloadNotSupported  = errors.New("reload not supported")
+	ErrSignalNotSubscribed = errors.New("signal not subscribed")
+	ErrInvalidSelector     = errors.New("invalid selector")
+	ErrNoServiceMatched    = errors.New("no service matched")
+	ErrInvalidPattern      = errors.New("invalid pattern")
 )
 
 var (
 	ErrRegisterServiceAlreadyExists = errors.New("service already exists")
-	ErrDeregisterServiceNotFound    = errors.New("service not found")
+	ErrDeregisterServiceNotFound    = ErrServiceNotFound
 	ErrRunnerAlreadyRunning         = errors.New("runner already running")
+	ErrShutdownSignal               = errors.New("received shutdown signal")
+	ErrCriticalServiceFailed        = errors.New("critical service failed")
 	ErrRegisterNilService           = errors.New("can not register nil service")
 	ErrUnsupportedOS                = errors.New("unsupported OS")
 	ErrSocketNoService              = errors.New("no service provided")
+	ErrInvalidSchedule              = errors.New("invalid schedule")
+	ErrInvalidLabel                 = errors.New("invalid label")
 )
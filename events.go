@@ -0,0 +1,116 @@
+package glcm
+
+import (
+	"sync"
+	"time"
+)
+
+// eventQueue collects the services which need to be reconciled.
+// The events of a service are coalesced till the runner drains the queue,
+// so that a burst of transitions leads to a single reconcile of the service.
+type eventQueue struct {
+	// mu is a mutex to protect the pending services and the timers.
+	mu *sync.Mutex
+
+	// pending is the set of services to be reconciled.
+	pending map[string]struct{}
+
+	// wake is signalled when a service is added to the pending set.
+	wake chan struct{}
+
+	// timers are the delayed events of the services, at most one per service.
+	timers map[string]*wakeTimer
+
+	// clock is the source of time for the delayed events.
+	clock Clock
+}
+
+// wakeTimer is a delayed event of a service.
+type wakeTimer struct {
+	timer Timer
+	at    time.Time
+}
+
+// newEventQueue returns a new instance of the event queue.
+func newEventQueue(clock Clock) *eventQueue {
+	return &eventQueue{
+		clock:   clock,
+		mu:      &sync.Mutex{},
+		pending: make(map[string]struct{}),
+		wake:    make(chan struct{}, 1),
+		timers:  make(map[string]*wakeTimer),
+	}
+}
+
+// push adds the service to the pending set and wakes up the runner.
+// It never blocks, so that it can be called while holding the locks of the service.
+func (q *eventQueue) push(name string) {
+	q.mu.Lock()
+	q.pending[name] = struct{}{}
+	q.mu.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// drain returns the pending services and clears the pending set.
+func (q *eventQueue) drain() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	names := make([]string, 0, len(q.pending))
+
+	for name := range q.pending {
+		names = append(names, name)
+	}
+
+	clear(q.pending)
+
+	return names
+}
+
+// wakeAt pushes an event for the service at the given time.
+// An earlier pending event of the service is kept, a later one is replaced.
+func (q *eventQueue) wakeAt(name string, at time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if t, ok := q.timers[name]; ok {
+		if !t.at.After(at) {
+			return
+		}
+
+		t.timer.Stop()
+	}
+
+	t := &wakeTimer{at: at}
+
+	t.timer = q.clock.AfterFunc(q.clock.Until(at), func() {
+		q.mu.Lock()
+
+		// the timer might have been replaced before it fired.
+		if q.timers[name] == t {
+			delete(q.timers, name)
+		}
+
+		q.mu.Unlock()
+
+		q.push(name)
+	})
+
+	q.timers[name] = t
+}
+
+// stop cancels the delayed events of all the services.
+func (q *eventQueue) stop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for name, t := range q.timers {
+		t.timer.Stop()
+
+		delete(q.timers, name)
+	}
+}
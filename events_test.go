@@ -0,0 +1,54 @@
+package glcm
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventQueuePush(t *testing.T) {
+	q := newEventQueue(realClock{})
+
+	q.push("svc1")
+	q.push("svc2")
+	q.push("svc1")
+
+	select {
+	case <-q.wake:
+	default:
+		t.Fatalf("Expected the queue to be woken up")
+	}
+
+	names := q.drain()
+	sort.Strings(names)
+
+	assert.Equal(t, []string{"svc1", "svc2"}, names, "Expected the events to be coalesced by service")
+	assert.Empty(t, q.drain(), "Expected the queue to be empty after draining")
+}
+
+func TestEventQueueWakeAt(t *testing.T) {
+	q := newEventQueue(realClock{})
+
+	// the earlier event is kept, the later one is ignored.
+	q.wakeAt("svc1", time.Now().Add(time.Millisecond*50))
+	q.wakeAt("svc1", time.Now().Add(time.Hour))
+
+	select {
+	case <-q.wake:
+		assert.Equal(t, []string{"svc1"}, q.drain(), "Expected the delayed event of the service")
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the delayed event to wake up the queue")
+	}
+
+	// the stopped events are not delivered.
+	q.wakeAt("svc2", time.Now().Add(time.Millisecond*50))
+	q.stop()
+
+	select {
+	case <-q.wake:
+		t.Fatalf("Expected no event after stopping the queue")
+	case <-time.After(time.Millisecond * 200):
+	}
+}
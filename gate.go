@@ -0,0 +1,96 @@
+package glcm
+
+import (
+	"sync"
+	"time"
+)
+
+// startGate limits the number of services which can be in the starting phase
+// (pre-hooks and start) at the same time and staggers the consecutive starts.
+type startGate struct {
+	// slots is a semaphore for the concurrent starts. nil means unlimited.
+	slots chan struct{}
+
+	// stagger is the minimum gap between two consecutive starts.
+	stagger time.Duration
+
+	// mu is a mutex to protect the next start time.
+	mu *sync.Mutex
+
+	// next is the earliest time at which the next start is allowed.
+	next time.Time
+
+	// clock is the source of time for the stagger.
+	clock Clock
+}
+
+// newStartGate returns a new instance of the start gate.
+func newStartGate(maxConcurrent int, stagger time.Duration, clock Clock) *startGate {
+	g := &startGate{
+		clock:   clock,
+		stagger: stagger,
+		mu:      &sync.Mutex{},
+	}
+
+	if maxConcurrent > 0 {
+		g.slots = make(chan struct{}, maxConcurrent)
+	}
+
+	return g
+}
+
+// acquire blocks until a start slot is available and the stagger gap has passed.
+// It returns false if the cancel channel is closed while waiting.
+// A successful acquire must be followed by a release.
+func (g *startGate) acquire(cancel <-chan struct{}) bool {
+	if g == nil {
+		return true
+	}
+
+	if g.slots != nil {
+		select {
+		case g.slots <- struct{}{}:
+		case <-cancel:
+			return false
+		}
+	}
+
+	if g.stagger <= 0 {
+		return true
+	}
+
+	// reserve the next start time, so that the waiting services are spread out.
+	g.mu.Lock()
+
+	at := g.clock.Now()
+	if g.next.After(at) {
+		at = g.next
+	}
+
+	g.next = at.Add(g.stagger)
+
+	g.mu.Unlock()
+
+	wait := g.clock.Until(at)
+	if wait <= 0 {
+		return true
+	}
+
+	select {
+	case <-g.clock.After(wait):
+		return true
+	case <-cancel:
+		g.release()
+
+		return false
+	}
+}
+
+// release frees the start slot acquired by the service.
+func (g *startGate) release() {
+	if g == nil || g.slots == nil {
+		return
+	}
+
+	<-g.slots
+}
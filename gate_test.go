@@ -0,0 +1,68 @@
+package glcm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartGate_NilGate(t *testing.T) {
+	var g *startGate
+
+	assert.True(t, g.acquire(nil), "Expected nil gate to allow the start")
+
+	g.release()
+}
+
+func TestStartGate_MaxConcurrentStarts(t *testing.T) {
+	g := newStartGate(2, 0, realClock{})
+
+	assert.True(t, g.acquire(nil), "Expected first start slot to be acquired")
+	assert.True(t, g.acquire(nil), "Expected second start slot to be acquired")
+
+	acquired := make(chan bool)
+
+	go func() {
+		acquired <- g.acquire(nil)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatalf("Expected third start to wait for a free slot")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	g.release()
+
+	select {
+	case ok := <-acquired:
+		assert.True(t, ok, "Expected third start slot to be acquired after release")
+	case <-time.After(time.Second):
+		t.Fatalf("Expected third start slot to be acquired after release")
+	}
+}
+
+func TestStartGate_Cancel(t *testing.T) {
+	g := newStartGate(1, 0, realClock{})
+
+	assert.True(t, g.acquire(nil), "Expected start slot to be acquired")
+
+	cancel := make(chan struct{})
+	close(cancel)
+
+	assert.False(t, g.acquire(cancel), "Expected cancelled start to not acquire a slot")
+}
+
+func TestStartGate_Stagger(t *testing.T) {
+	g := newStartGate(0, time.Millisecond*100, realClock{})
+
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, g.acquire(nil), "Expected start slot to be acquired")
+		g.release()
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*200, "Expected the starts to be staggered")
+}
@@ -0,0 +1,206 @@
+// Package glcmtest provides helpers for testing the services built on glcm.
+package glcmtest
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/achu-1612/glcm"
+)
+
+var _ glcm.Terminator = &Terminator{}
+
+// TB is the subset of testing.TB used by the harness to report failures.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// Terminator implements the glcm.Terminator interface, to run a service without a runner.
+type Terminator struct {
+	// tc is closed when the service is to be terminated.
+	tc chan struct{}
+
+	// once guards the closing of the termination channel.
+	once *sync.Once
+
+	// mu is a mutex to protect the subscribed signals.
+	mu *sync.Mutex
+
+	// sigs is the set of the signals subscribed by the service.
+	sigs map[os.Signal]struct{}
+
+	// sigCh is the channel on which the signals are delivered to the service.
+	sigCh chan os.Signal
+}
+
+// NewTerminator returns a new instance of the Terminator.
+func NewTerminator() *Terminator {
+	return &Terminator{
+		tc:    make(chan struct{}),
+		once:  &sync.Once{},
+		mu:    &sync.Mutex{},
+		sigs:  make(map[os.Signal]struct{}),
+		sigCh: make(chan os.Signal, 1),
+	}
+}
+
+// TermCh returns the termination channel for the service.
+func (t *Terminator) TermCh() chan struct{} {
+	return t.tc
+}
+
+// Signals subscribes the service to the given signals, which are sent with Signal.
+func (t *Terminator) Signals(sig ...os.Signal) <-chan os.Signal {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, s := range sig {
+		t.sigs[s] = struct{}{}
+	}
+
+	return t.sigCh
+}
+
+// Signal delivers the signal to the service, as the runner does for a process signal.
+// It returns false if the service has not subscribed to the signal, or the previous signal is not received yet.
+func (t *Terminator) Signal(sig os.Signal) bool {
+	t.mu.Lock()
+	_, ok := t.sigs[sig]
+	t.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	select {
+	case t.sigCh <- sig:
+		return true
+	default:
+		return false
+	}
+}
+
+// Terminate closes the termination channel. It is safe to call it more than once.
+func (t *Terminator) Terminate() {
+	t.once.Do(func() {
+		close(t.tc)
+	})
+}
+
+// Terminated returns true if the termination channel is closed.
+func (t *Terminator) Terminated() bool {
+	select {
+	case <-t.tc:
+		return true
+	default:
+		return false
+	}
+}
+
+// Harness runs a single service under a fake Terminator.
+// The service is terminated when the test finishes, if it is still running.
+type Harness struct {
+	t TB
+
+	// svc is the service under test.
+	svc glcm.Service
+
+	// term is the terminator passed to the service.
+	term *Terminator
+
+	// done is closed when the Start method of the service returns.
+	done chan struct{}
+}
+
+// NewHarness returns a new instance of the harness for the given service.
+func NewHarness(t TB, svc glcm.Service) *Harness {
+	h := &Harness{
+		t:    t,
+		svc:  svc,
+		term: NewTerminator(),
+		done: make(chan struct{}),
+	}
+
+	t.Cleanup(h.term.Terminate)
+
+	return h
+}
+
+// Start starts the service in a goroutine. It returns the harness for chaining.
+func (h *Harness) Start() *Harness {
+	go func() {
+		defer close(h.done)
+
+		h.svc.Start(h.term)
+	}()
+
+	return h
+}
+
+// Terminator returns the terminator passed to the service.
+func (h *Harness) Terminator() *Terminator {
+	return h.term
+}
+
+// Done returns a channel which is closed when the Start method of the service returns.
+func (h *Harness) Done() <-chan struct{} {
+	return h.done
+}
+
+// Exited returns true if the Start method of the service has returned.
+func (h *Harness) Exited() bool {
+	select {
+	case <-h.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// AssertRunningFor asserts that the service keeps running for the given duration.
+func (h *Harness) AssertRunningFor(d time.Duration) bool {
+	h.t.Helper()
+
+	select {
+	case <-h.done:
+		h.t.Errorf("service %s exited, expected it to keep running for %s", h.svc.Name(), d)
+
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// AssertExitsWithin asserts that the service exits on its own with in the given duration.
+func (h *Harness) AssertExitsWithin(d time.Duration) bool {
+	h.t.Helper()
+
+	select {
+	case <-h.done:
+		return true
+	case <-time.After(d):
+		h.t.Errorf("service %s did not exit with in %s", h.svc.Name(), d)
+
+		return false
+	}
+}
+
+// AssertStopsWithin terminates the service and asserts that it honours the
+// termination channel, by returning with in the given duration.
+func (h *Harness) AssertStopsWithin(d time.Duration) bool {
+	h.t.Helper()
+
+	h.term.Terminate()
+
+	select {
+	case <-h.done:
+		return true
+	case <-time.After(d):
+		h.t.Errorf("service %s did not stop with in %s after termination", h.svc.Name(), d)
+
+		return false
+	}
+}
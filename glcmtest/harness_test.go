@@ -0,0 +1,112 @@
+package glcmtest
+
+import (
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/achu-1612/glcm"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTB records the failures reported by the harness.
+type fakeTB struct {
+	errors   []string
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+// politeService stops when it is terminated.
+type politeService struct{}
+
+func (politeService) Name() string { return "polite" }
+
+func (politeService) Start(t glcm.Terminator) { <-t.TermCh() }
+
+// stubbornService ignores the termination channel.
+type stubbornService struct{ release chan struct{} }
+
+func (stubbornService) Name() string { return "stubborn" }
+
+func (s stubbornService) Start(glcm.Terminator) { <-s.release }
+
+func TestTerminator(t *testing.T) {
+	term := NewTerminator()
+
+	assert.False(t, term.Terminated(), "Expected the terminator to not be terminated")
+
+	term.Terminate()
+	term.Terminate()
+
+	assert.True(t, term.Terminated(), "Expected the terminator to be terminated")
+}
+
+func TestTerminatorSignals(t *testing.T) {
+	term := NewTerminator()
+
+	assert.False(t, term.Signal(syscall.SIGUSR1), "Expected no delivery before the subscription")
+
+	sigs := term.Signals(syscall.SIGUSR1)
+
+	assert.True(t, term.Signal(syscall.SIGUSR1), "Expected the subscribed signal to be delivered")
+	assert.False(t, term.Signal(syscall.SIGUSR1), "Expected the signal to be dropped while the previous one is pending")
+	assert.Equal(t, syscall.SIGUSR1, <-sigs, "Expected the signal to be received")
+}
+
+func TestHarnessAssertStopsWithin(t *testing.T) {
+	tests := []struct {
+		name     string
+		svc      glcm.Service
+		wantStop bool
+	}{
+		{
+			name:     "Service honours the termination",
+			svc:      politeService{},
+			wantStop: true,
+		},
+		{
+			name:     "Service ignores the termination",
+			svc:      stubbornService{release: make(chan struct{})},
+			wantStop: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &fakeTB{}
+
+			h := NewHarness(tb, tt.svc).Start()
+
+			assert.True(t, h.AssertRunningFor(time.Millisecond*50), "Expected the service to keep running")
+			assert.Equal(t, tt.wantStop, h.AssertStopsWithin(time.Millisecond*100), "Unexpected stop assertion")
+			assert.Equal(t, !tt.wantStop, len(tb.errors) == 1, "Unexpected failures: %v", tb.errors)
+			assert.True(t, h.Terminator().Terminated(), "Expected the service to be terminated")
+			assert.Len(t, tb.cleanups, 1, "Expected the termination to be registered for cleanup")
+
+			if s, ok := tt.svc.(stubbornService); ok {
+				close(s.release)
+			}
+		})
+	}
+}
+
+func TestHarnessAssertExitsWithin(t *testing.T) {
+	tb := &fakeTB{}
+
+	h := NewHarness(tb, NewFlakyService("flaky", 1)).Start()
+
+	assert.True(t, h.AssertExitsWithin(time.Second), "Expected the failing start to exit")
+	assert.True(t, h.Exited(), "Expected the service to be exited")
+	assert.False(t, h.AssertRunningFor(time.Millisecond), "Expected the running assertion to fail")
+	assert.Len(t, tb.errors, 1, "Expected the failure to be reported")
+}
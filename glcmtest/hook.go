@@ -0,0 +1,64 @@
+package glcmtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/achu-1612/glcm"
+)
+
+var _ glcm.Hook = &RecorderHook{}
+
+// RecorderHook is a hook which records its executions.
+type RecorderHook struct {
+	name string
+
+	// err is the error returned on every execution.
+	err error
+
+	// mu is a mutex to protect the executions.
+	mu *sync.Mutex
+
+	// calls are the times of the executions.
+	calls []time.Time
+}
+
+// NewRecorderHook returns a new instance of the recorder hook, which returns the given error on every execution.
+func NewRecorderHook(name string, err error) *RecorderHook {
+	return &RecorderHook{
+		name: name,
+		err:  err,
+		mu:   &sync.Mutex{},
+	}
+}
+
+// Execute records the execution and returns the error of the hook.
+func (h *RecorderHook) Execute() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.calls = append(h.calls, time.Now())
+
+	return h.err
+}
+
+// Name returns the name of the hook.
+func (h *RecorderHook) Name() string {
+	return h.name
+}
+
+// Calls returns the number of executions.
+func (h *RecorderHook) Calls() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.calls)
+}
+
+// Times returns the times of the executions, oldest first.
+func (h *RecorderHook) Times() []time.Time {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]time.Time(nil), h.calls...)
+}
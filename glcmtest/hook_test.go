@@ -0,0 +1,27 @@
+package glcmtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorderHook(t *testing.T) {
+	errHook := errors.New("hook failed")
+
+	h := NewRecorderHook("recorder", errHook)
+
+	assert.Equal(t, "recorder", h.Name(), "Expected the name of the hook")
+	assert.Zero(t, h.Calls(), "Expected no executions")
+
+	assert.ErrorIs(t, h.Execute(), errHook, "Expected the error of the hook")
+	assert.ErrorIs(t, h.Execute(), errHook, "Expected the error of the hook")
+
+	assert.Equal(t, 2, h.Calls(), "Expected the executions to be recorded")
+
+	times := h.Times()
+	if assert.Len(t, times, 2, "Expected the execution times") {
+		assert.False(t, times[1].Before(times[0]), "Expected the execution times oldest first")
+	}
+}
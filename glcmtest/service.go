@@ -0,0 +1,77 @@
+package glcmtest
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/achu-1612/glcm"
+)
+
+var (
+	_ glcm.Service = &FlakyService{}
+	_ glcm.Job     = &FlakyService{}
+)
+
+// FlakyService is a service which exits on its own for its first N starts,
+// then runs till it is terminated. It is meant for testing the auto-restart and backoff.
+// It implements the glcm.Job interface, reporting an error for the failed runs.
+type FlakyService struct {
+	name string
+
+	// failures is the number of starts which fail.
+	failures int
+
+	// mu is a mutex to protect the starts.
+	mu *sync.Mutex
+
+	// starts is the number of times the service has been started.
+	starts int
+}
+
+// NewFlakyService returns a new instance of the flaky service, which fails the given number of starts.
+func NewFlakyService(name string, failures int) *FlakyService {
+	return &FlakyService{
+		name:     name,
+		failures: failures,
+		mu:       &sync.Mutex{},
+	}
+}
+
+// Name returns the name of the service.
+func (f *FlakyService) Name() string {
+	return f.name
+}
+
+// Start returns right away for the failing starts, otherwise it blocks till the service is terminated.
+func (f *FlakyService) Start(t glcm.Terminator) {
+	f.mu.Lock()
+	f.starts++
+	failed := f.starts <= f.failures
+	f.mu.Unlock()
+
+	if failed {
+		return
+	}
+
+	<-t.TermCh()
+}
+
+// Starts returns the number of times the service has been started.
+func (f *FlakyService) Starts() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.starts
+}
+
+// Result returns the number of starts, along with an error if the last start has failed.
+func (f *FlakyService) Result() (interface{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.starts <= f.failures {
+		return nil, fmt.Errorf("start %d of %d failed", f.starts, f.failures)
+	}
+
+	return f.starts, nil
+}
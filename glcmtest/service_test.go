@@ -0,0 +1,31 @@
+package glcmtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlakyService(t *testing.T) {
+	svc := NewFlakyService("flaky", 2)
+
+	for i := 1; i <= 2; i++ {
+		h := NewHarness(t, svc).Start()
+
+		h.AssertExitsWithin(time.Second)
+
+		_, err := svc.Result()
+		assert.Error(t, err, "Expected start %d to fail", i)
+	}
+
+	h := NewHarness(t, svc).Start()
+
+	h.AssertRunningFor(time.Millisecond * 50)
+	h.AssertStopsWithin(time.Second)
+
+	res, err := svc.Result()
+	assert.Nil(t, err, "Expected the third start to succeed")
+	assert.Equal(t, 3, res, "Expected the number of starts as the result")
+	assert.Equal(t, 3, svc.Starts(), "Expected the starts to be counted")
+}
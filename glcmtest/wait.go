@@ -0,0 +1,17 @@
+package glcmtest
+
+import (
+	"context"
+	"time"
+
+	"github.com/achu-1612/glcm"
+)
+
+// WaitForStatus waits till the given service of the runner is in the given status.
+// It returns an error, with the last seen status, if the service is not in the status with in the timeout.
+func WaitForStatus(r glcm.Runner, name string, status glcm.ServiceStatus, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return r.WaitForStatus(ctx, name, status)
+}
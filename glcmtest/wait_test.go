@@ -0,0 +1,39 @@
+package glcmtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/achu-1612/glcm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForStatus(t *testing.T) {
+	r := glcm.NewRunner(context.Background(), glcm.RunnerOptions{HideBanner: true})
+
+	svc := NewFlakyService("flaky", 2)
+	pre := NewRecorderHook("pre", nil)
+
+	err := r.RegisterService(svc, glcm.ServiceOptions{
+		PreHooks:  []glcm.Hook{pre},
+		AutoStart: glcm.AutoRestartOptions{Enabled: true, MaxRetries: 5},
+	})
+	assert.Nil(t, err, "Expected no error for registering service")
+
+	go func() {
+		if err := r.BootUp(); err != nil {
+			t.Errorf("Error while booting up the runner: %v", err)
+		}
+	}()
+
+	defer r.Shutdown()
+
+	// the service is running after the two failed starts.
+	assert.Nil(t, WaitForStatus(r, "flaky", glcm.ServiceStatusRunning, time.Second*5))
+	assert.Equal(t, 3, svc.Starts(), "Expected the service to be restarted after the failures")
+	assert.Equal(t, 3, pre.Calls(), "Expected the pre-hook on every start")
+
+	assert.Error(t, WaitForStatus(r, "flaky", glcm.ServiceStatusStopped, time.Millisecond*50), "Expected a timeout")
+	assert.Error(t, WaitForStatus(r, "unknown", glcm.ServiceStatusRunning, time.Millisecond*50), "Expected an error for unknown service")
+}
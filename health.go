@@ -0,0 +1,138 @@
+package glcm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// severity returns the rank of the health state, the higher the worse.
+func (h HealthState) severity() int {
+	switch h {
+	case HealthDegraded:
+		return 1
+	case HealthUnhealthy:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// HealthRule evaluates a service and returns the health state it leads to, along with the reason.
+// A healthy state means the service does not affect the health of the runner.
+type HealthRule func(name string, info ServiceInfo) (HealthState, string)
+
+// DefaultHealthRules returns the health rules used, when no rule is given in the health options.
+func DefaultHealthRules() []HealthRule {
+	return []HealthRule{CriticalReadyRule, FailedServiceRule, HealthCheckRule}
+}
+
+// CriticalReadyRule makes the runner unhealthy, while a critical service is not ready.
+func CriticalReadyRule(name string, info ServiceInfo) (HealthState, string) {
+	if info.Critical && !info.Ready {
+		return HealthUnhealthy, fmt.Sprintf("critical service %s is %s", name, info.Status)
+	}
+
+	return HealthHealthy, ""
+}
+
+// FailedServiceRule makes the runner degraded, while a service is down on its own or held back by its conditions.
+func FailedServiceRule(name string, info ServiceInfo) (HealthState, string) {
+	switch info.Status {
+	case ServiceStatusExited, ServiceStatusExhausted, ServiceStatusScheduledForRestart, ServiceStatusConditionUnmet:
+		return HealthDegraded, fmt.Sprintf("service %s is %s", name, info.Status)
+	}
+
+	return HealthHealthy, ""
+}
+
+// HealthCheckRule makes the runner degraded (unhealthy for a critical service), while the health check of a service fails.
+func HealthCheckRule(name string, info ServiceInfo) (HealthState, string) {
+	if info.Check == nil || info.Check.Error == "" {
+		return HealthHealthy, ""
+	}
+
+	state := HealthDegraded
+	if info.Critical {
+		state = HealthUnhealthy
+	}
+
+	return state, fmt.Sprintf("health check of service %s failed: %s", name, info.Check.Error)
+}
+
+// evaluateHealth computes the overall health of the runner from the status of the services.
+// The worst state given by the rules is the overall state.
+func evaluateHealth(status *RunnerStatus, rules []HealthRule) *Health {
+	h := &Health{State: HealthHealthy}
+
+	worsen := func(state HealthState, reason string) {
+		if state.severity() == 0 {
+			return
+		}
+
+		if state.severity() > h.State.severity() {
+			h.State = state
+		}
+
+		h.Reasons = append(h.Reasons, reason)
+	}
+
+	if !status.IsRunning {
+		worsen(HealthUnhealthy, "runner is not running")
+	}
+
+	names := make([]string, 0, len(status.Services))
+
+	for name := range status.Services {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, rule := range rules {
+			worsen(rule(name, status.Services[name]))
+		}
+	}
+
+	return h
+}
+
+// Health returns the overall health of the runner, based on the last health checks of the services.
+func (r *runner) Health() *Health {
+	return r.Status().Health
+}
+
+// checkHealth runs the health checks of the running services concurrently.
+// A round of checks is skipped, if the previous one is still going.
+func (r *runner) checkHealth() {
+	if !r.checking.CompareAndSwap(false, true) {
+		return
+	}
+
+	defer r.checking.Store(false)
+
+	wg := &sync.WaitGroup{}
+
+	for _, w := range r.services() {
+		if w.Status() != ServiceStatusRunning {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(w Wrapper) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(r.ctx, r.health.CheckTimeout)
+			defer cancel()
+
+			w.CheckHealth(ctx)
+		}(w)
+	}
+
+	wg.Wait()
+
+	r.changes.notify()
+}
@@ -0,0 +1,181 @@
+package glcm
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateHealth(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      *RunnerStatus
+		rules       []HealthRule
+		wantState   HealthState
+		wantReasons []string
+	}{
+		{
+			name: "All services ready",
+			status: &RunnerStatus{
+				IsRunning: true,
+				Services: map[string]ServiceInfo{
+					"api": {Status: ServiceStatusRunning, Ready: true, Critical: true},
+					"job": {Status: ServiceStatusCompleted, Ready: true},
+				},
+			},
+			wantState: HealthHealthy,
+		},
+		{
+			name:        "Runner not running",
+			status:      &RunnerStatus{Services: map[string]ServiceInfo{}},
+			wantState:   HealthUnhealthy,
+			wantReasons: []string{"runner is not running"},
+		},
+		{
+			name: "Non-critical service exited",
+			status: &RunnerStatus{
+				IsRunning: true,
+				Services: map[string]ServiceInfo{
+					"api":    {Status: ServiceStatusRunning, Ready: true},
+					"worker": {Status: ServiceStatusExited},
+				},
+			},
+			wantState:   HealthDegraded,
+			wantReasons: []string{"service worker is exited"},
+		},
+		{
+			name: "Critical service not ready",
+			status: &RunnerStatus{
+				IsRunning: true,
+				Services: map[string]ServiceInfo{
+					"api":    {Status: ServiceStatusScheduledForRestart, Critical: true},
+					"worker": {Status: ServiceStatusConditionUnmet},
+				},
+			},
+			wantState: HealthUnhealthy,
+			wantReasons: []string{
+				"critical service api is scheduled-for-restart",
+				"service api is scheduled-for-restart",
+				"service worker is condition-unmet",
+			},
+		},
+		{
+			name: "Failing health checks",
+			status: &RunnerStatus{
+				IsRunning: true,
+				Services: map[string]ServiceInfo{
+					"api":   {Status: ServiceStatusRunning, Ready: true, Check: &HealthCheckResult{Error: "timeout"}},
+					"cache": {Status: ServiceStatusRunning, Ready: true, Check: &HealthCheckResult{}},
+				},
+			},
+			wantState:   HealthDegraded,
+			wantReasons: []string{"health check of service api failed: timeout"},
+		},
+		{
+			name: "Failing health check of a critical service",
+			status: &RunnerStatus{
+				IsRunning: true,
+				Services: map[string]ServiceInfo{
+					"api": {Status: ServiceStatusRunning, Ready: true, Critical: true, Check: &HealthCheckResult{Error: "timeout"}},
+				},
+			},
+			wantState:   HealthUnhealthy,
+			wantReasons: []string{"health check of service api failed: timeout"},
+		},
+		{
+			name: "Custom rules",
+			status: &RunnerStatus{
+				IsRunning: true,
+				Services: map[string]ServiceInfo{
+					"worker": {Status: ServiceStatusExited},
+				},
+			},
+			rules: []HealthRule{
+				func(name string, info ServiceInfo) (HealthState, string) {
+					if info.Status == ServiceStatusExited {
+						return HealthUnhealthy, name + " is down"
+					}
+
+					return HealthHealthy, ""
+				},
+			},
+			wantState:   HealthUnhealthy,
+			wantReasons: []string{"worker is down"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := tt.rules
+			if rules == nil {
+				rules = DefaultHealthRules()
+			}
+
+			h := evaluateHealth(tt.status, rules)
+
+			assert.Equal(t, tt.wantState, h.State, "Unexpected health state")
+			assert.Equal(t, tt.wantReasons, h.Reasons, "Unexpected health reasons")
+		})
+	}
+}
+
+// checkedService is a long running service with a health check, which fails when it is told so.
+type checkedService struct {
+	failing atomic.Bool
+}
+
+func (c *checkedService) Start(t Terminator) {
+	<-t.TermCh()
+}
+
+func (c *checkedService) Name() string {
+	return "checkedService"
+}
+
+func (c *checkedService) HealthCheck(context.Context) error {
+	if c.failing.Load() {
+		return errors.New("connection refused")
+	}
+
+	return nil
+}
+
+func TestRunnerHealth(t *testing.T) {
+	r := NewRunner(context.Background(), RunnerOptions{
+		HideBanner: true,
+		Signals:    SignalOptions{Disabled: true},
+		Health:     HealthOptions{CheckInterval: time.Millisecond * 20},
+	})
+
+	assert.Equal(t, HealthUnhealthy, r.Health().State, "Expected the runner to be unhealthy before it is started")
+
+	svc := &checkedService{}
+
+	err := r.RegisterService(svc, ServiceOptions{})
+	assert.Nil(t, err, "Expected no error for registering service")
+
+	assert.Nil(t, r.Start(context.Background()), "Expected no error starting the runner")
+
+	defer r.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+
+	err = r.WaitForAll(ctx, func(s *RunnerStatus) bool {
+		return s.Health.State == HealthHealthy && s.Services["checkedService"].Check != nil
+	})
+	assert.Nil(t, err, "Expected the runner to be healthy once the service is checked")
+
+	svc.failing.Store(true)
+
+	err = r.WaitForAll(ctx, func(s *RunnerStatus) bool {
+		return s.Health.State == HealthDegraded
+	})
+	assert.Nil(t, err, "Expected the runner to be degraded by the failing health check")
+
+	assert.Equal(t, []string{"health check of service checkedService failed: connection refused"}, r.Health().Reasons)
+}
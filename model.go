@@ -1,6 +1,10 @@
 package glcm
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
 	"time"
 
 	"github.com/achu-1612/glcm/log"
@@ -11,6 +15,11 @@ const (
 	defaultShutdownTimeout = time.Second * 30
 	defaultMaxRetries      = 10
 	defaultBackoffExp      = 2
+	defaultMaxMissedRuns   = 10
+	defaultHistorySize     = 20
+	defaultResyncInterval  = time.Second * 30
+	defaultHealthInterval  = time.Second * 10
+	defaultHealthTimeout   = time.Second * 5
 )
 
 const (
@@ -29,8 +38,24 @@ const (
 	`
 )
 
+// ServiceType represents the type of the service.
+type ServiceType string
+
+// Service type options.
+const (
+	// ServiceTypeSimple is a long running service, which is expected to run till it is stopped.
+	ServiceTypeSimple ServiceType = "simple"
+
+	// ServiceTypeOneshot is a service which runs to completion.
+	// A successful return marks the service as completed.
+	ServiceTypeOneshot ServiceType = "oneshot"
+)
+
 // ServiceOptions represents the options for a service.
 type ServiceOptions struct {
+	// Type represents the type of the service. Defaults to simple.
+	Type ServiceType
+
 	// PreHooks are the hooks that are executed before the service is started.
 	PreHooks []Hook
 
@@ -42,10 +67,71 @@ type ServiceOptions struct {
 
 	// Schedule represents the options for scheduling the service.
 	Schedule SchedulingOptions
+
+	// Every represents the options for running the service periodically.
+	Every PeriodicOptions
+
+	// Conditions are the predicates which are evaluated before each start attempt.
+	// The service is not started till all the conditions are met.
+	Conditions []Condition
+
+	// ActiveWindows are the time windows in which the service may run.
+	// The service is stopped gracefully when a window ends and started again when the next window opens.
+	// Empty means the service may run at any time.
+	ActiveWindows []ActiveWindow
+
+	// StartDelay represents the delay before the service is started for the first time.
+	StartDelay time.Duration
+
+	// HistorySize represents the number of recent status transitions kept for the service.
+	HistorySize int
+
+	// ReloadFallback represents if the service should be restarted, when its reload fails
+	// or the service does not implement the Reloader interface.
+	ReloadFallback bool
+
+	// Critical represents if the runner should be shut down, when the service fails for good.
+	// i.e. it is exhausted, or it exits on its own (or panics) without auto-restart.
+	Critical bool
+
+	// Description represents the human readable description of the service.
+	Description string
+
+	// Owner represents the owner (e.g. the team) of the service.
+	Owner string
+
+	// Version represents the version of the service.
+	Version string
+
+	// Labels represents the arbitrary key/value labels of the service, by which the services can be selected
+	// in the control operations, e.g. tier=ingest,env!=canary.
+	Labels map[string]string
 }
 
 // Sanitize fills the default values for the service options.
 func (s *ServiceOptions) Sanitize() {
+	if s.Type == "" {
+		s.Type = ServiceTypeSimple
+	}
+
+	if s.Every.Interval > 0 && s.Every.Overlap == "" {
+		s.Every.Overlap = OverlapSkip
+	}
+
+	if s.Schedule.Enabled && s.Schedule.MissedRuns == "" {
+		s.Schedule.MissedRuns = MissedRunSkip
+	}
+
+	if s.Schedule.MissedRuns == MissedRunAll && s.Schedule.MaxMissedRuns == 0 {
+		log.Warnf("MaxMissedRuns is not set for service. Setting it to default value %d", defaultMaxMissedRuns)
+
+		s.Schedule.MaxMissedRuns = defaultMaxMissedRuns
+	}
+
+	if s.HistorySize == 0 {
+		s.HistorySize = defaultHistorySize
+	}
+
 	if s.AutoStart.MaxRetries == 0 {
 		log.Warnf("MaxRetries is not set for service. Setting it to default value %d", defaultMaxRetries)
 
@@ -74,12 +160,31 @@ type AutoRestartOptions struct {
 	BackOffExponent int
 }
 
+// MissedRunPolicy represents the action taken for the scheduled runs,
+// which were missed while the runner or the service was down.
+type MissedRunPolicy string
+
+// Missed run policy options.
+const (
+	// MissedRunSkip skips the missed runs.
+	MissedRunSkip MissedRunPolicy = "skip"
+
+	// MissedRunOnce runs the service once on recovery, if any run was missed.
+	MissedRunOnce MissedRunPolicy = "once"
+
+	// MissedRunAll runs the service for every missed run, up to the MaxMissedRuns.
+	MissedRunAll MissedRunPolicy = "all"
+)
+
 // SchedulingOptions represents the options for scheduling the service.
+// Each scheduled run calls the Start method of the service with its own Terminator.
 type SchedulingOptions struct {
-	// Enabled represents if the auto-restart is enabled.
+	// Enabled represents if the scheduling is enabled.
 	Enabled bool
 
 	// Cron represents the cron expression for scheduling the service.
+	// The standard five fields (minute, hour, day of month, month, day of week)
+	// and the descriptors like @hourly and @daily are supported.
 	Cron string
 
 	// TimeOut represents the timeout for the service.
@@ -87,7 +192,48 @@ type SchedulingOptions struct {
 	TimeOut time.Duration
 
 	// MaxRuns represents the maximum number of runs for the service.
+	// The service is marked as completed after the last run. 0 means unlimited.
 	MaxRuns int
+
+	// MissedRuns represents the policy for the missed runs. Defaults to skip.
+	MissedRuns MissedRunPolicy
+
+	// MaxMissedRuns represents the maximum number of missed runs to catch up with the all policy.
+	MaxMissedRuns int
+}
+
+// OverlapPolicy represents the action taken when a periodic run is due,
+// while the previous run of the service is still going.
+type OverlapPolicy string
+
+// Overlap policy options.
+const (
+	// OverlapSkip skips the due run.
+	OverlapSkip OverlapPolicy = "skip"
+
+	// OverlapQueue starts the due run once the previous run is finished.
+	// At most one run is queued at a time.
+	OverlapQueue OverlapPolicy = "queue"
+
+	// OverlapTerminate terminates the previous run and starts the due run.
+	OverlapTerminate OverlapPolicy = "terminate"
+)
+
+// PeriodicOptions represents the options for running the service periodically.
+// Each run calls the Start method of the service with its own Terminator.
+type PeriodicOptions struct {
+	// Interval represents the interval between the runs. 0 disables the periodic runs.
+	Interval time.Duration
+
+	// InitialDelay represents the delay before the first run.
+	InitialDelay time.Duration
+
+	// Jitter represents the maximum random duration added to each interval.
+	Jitter time.Duration
+
+	// Overlap represents the policy when a run is due while the previous run is still going.
+	// Defaults to skip.
+	Overlap OverlapPolicy
 }
 
 // RunnerOptions represents the options for the runner.
@@ -109,6 +255,113 @@ type RunnerOptions struct {
 
 	// ShutdownTimeout represents the timeout for shutting down the runner.
 	ShutdownTimeout time.Duration
+
+	// StateFile represents the path to the file in which the schedule state of the services is persisted.
+	// If empty, the state is kept in memory only and the runs missed while the runner was down are not known.
+	StateFile string
+
+	// StartStagger represents the minimum gap between two consecutive service starts.
+	StartStagger time.Duration
+
+	// MaxConcurrentStarts represents the maximum number of services which can be
+	// in the starting phase (pre-hooks and start) at the same time. 0 means unlimited.
+	MaxConcurrentStarts int
+
+	// ResyncInterval represents the interval at which all the services are reconciled,
+	// in addition to the reconciles triggered by the status changes of the services.
+	ResyncInterval time.Duration
+
+	// Clock represents the source of time for the runner and the services.
+	// Defaults to the system clock. A FakeClock can be used to test the time based behaviour.
+	Clock Clock
+
+	// Signals represents the handling of the OS signals by the runner.
+	Signals SignalOptions
+
+	// Health represents the options for computing the health of the runner.
+	Health HealthOptions
+}
+
+// HealthOptions represents the options for computing the health of the runner.
+type HealthOptions struct {
+	// CheckInterval represents the interval at which the health checks of the running services are run.
+	// Defaults to 10 seconds.
+	CheckInterval time.Duration
+
+	// CheckTimeout represents the timeout for a single health check. Defaults to 5 seconds.
+	CheckTimeout time.Duration
+
+	// Rules are the rules which evaluate each service for the health of the runner.
+	// The worst state given by the rules is the health of the runner. Defaults to DefaultHealthRules.
+	Rules []HealthRule
+}
+
+// Sanitize fills the default values for the health options.
+func (h *HealthOptions) Sanitize() {
+	if h.CheckInterval == 0 {
+		h.CheckInterval = defaultHealthInterval
+	}
+
+	if h.CheckTimeout == 0 {
+		h.CheckTimeout = defaultHealthTimeout
+	}
+
+	if h.Rules == nil {
+		h.Rules = DefaultHealthRules()
+	}
+}
+
+// SignalOptions represents the handling of the OS signals by the runner.
+type SignalOptions struct {
+	// Disabled represents if the OS signals should not be handled by the runner, e.g. for library and test use.
+	// The signals sent on the Trigger are still handled.
+	Disabled bool
+
+	// Shutdown represents the signals which shut down the runner.
+	// Defaults to SIGTERM, SIGINT and SIGQUIT (and SIGHUP, if the reload signals are given), except the ones given
+	// for the other actions. An empty (non-nil) list means no signal shuts down the runner.
+	Shutdown []os.Signal
+
+	// Reload represents the signals which reload the running services.
+	// Defaults to SIGHUP, unless it is given for the other actions. The default SIGHUP still shuts down the runner,
+	// if no registered service implements the Reloader interface. An empty (non-nil) list means no signal reloads.
+	Reload []os.Signal
+
+	// Status represents the signals which dump the status of the services to the logs.
+	Status []os.Signal
+
+	// Ignore represents the signals which are ignored while the runner is running.
+	Ignore []os.Signal
+
+	// Trigger represents a channel on which the signals can be delivered to the runner, in addition to the OS.
+	// It can be used as a custom shutdown trigger, or to send the signals in the tests.
+	Trigger <-chan os.Signal
+
+	// defaultReload represents if the reload signal is the default SIGHUP, which shuts down the runner
+	// when there is no service to reload.
+	defaultReload bool
+}
+
+// Sanitize fills the default values for the signal options.
+func (s *SignalOptions) Sanitize() {
+	if s.Reload == nil && !hasSignal(s.Shutdown, defaultReloadSignal) &&
+		!hasSignal(s.Status, defaultReloadSignal) && !hasSignal(s.Ignore, defaultReloadSignal) {
+		s.Reload = []os.Signal{defaultReloadSignal}
+		s.defaultReload = true
+	}
+
+	if s.Shutdown != nil {
+		return
+	}
+
+	s.Shutdown = []os.Signal{}
+
+	// a default shutdown signal can be used for the other actions (e.g. SIGHUP to reload).
+	for _, sig := range defaultShutdownSignals {
+		if !hasSignal(s.Reload, sig) && !hasSignal(s.Status, sig) && !hasSignal(s.Ignore, sig) {
+			s.Shutdown = append(s.Shutdown, sig)
+		}
+	}
 }
 
 // Santizie fills the default values for the runner options.
@@ -124,17 +377,192 @@ func (r *RunnerOptions) Sanitize() {
 
 		r.SocketPath = defaultSocketPath
 	}
+
+	if r.ResyncInterval == 0 {
+		log.Warnf("ResyncInterval is not set for runner. Setting it to default value %v", defaultResyncInterval)
+
+		r.ResyncInterval = defaultResyncInterval
+	}
+
+	if r.Clock == nil {
+		r.Clock = realClock{}
+	}
+
+	r.Signals.Sanitize()
+	r.Health.Sanitize()
+}
+
+// ServiceResults represents the outcome of an operation for each of the services by name.
+// A nil error means the operation has succeeded for the service.
+type ServiceResults map[string]error
+
+// Err returns the errors of the failed services joined together, nil if the operation has succeeded for all.
+func (s ServiceResults) Err() error {
+	names := make([]string, 0, len(s))
+
+	for name, err := range s {
+		if err != nil {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	errs := make([]error, 0, len(names))
+
+	for _, name := range names {
+		errs = append(errs, fmt.Errorf("%s: %w", name, s[name]))
+	}
+
+	return errors.Join(errs...)
+}
+
+// OperationState represents the state of an asynchronous operation.
+type OperationState string
+
+// Operation state options.
+const (
+	OperationRunning   OperationState = "running"
+	OperationSucceeded OperationState = "succeeded"
+	OperationFailed    OperationState = "failed"
+)
+
+// Operation represents an asynchronous control operation on the services.
+type Operation struct {
+	ID        string                    `json:"id"`
+	Action    string                    `json:"action"`
+	Services  []string                  `json:"services"`
+	State     OperationState            `json:"state"`
+	Results   map[string]ServiceOutcome `json:"results,omitempty"`
+	StartTime time.Time                 `json:"startTime"`
+	EndTime   time.Time                 `json:"endTime"`
+
+	// err is the joined errors of the failed services.
+	err error
+}
+
+// Err returns the errors of the failed services joined together, nil if the operation is running or has succeeded.
+func (o *Operation) Err() error {
+	return o.err
 }
 
 // RunnerStatus represents the status of the runner.
 type RunnerStatus struct {
 	IsRunning bool                   `json:"isRunning"`
 	Services  map[string]ServiceInfo `json:"services"`
+	Health    *Health                `json:"health,omitempty"`
+}
+
+// HealthState represents the overall health of the runner.
+type HealthState string
+
+// Health state options, from the best to the worst.
+const (
+	HealthHealthy   HealthState = "healthy"
+	HealthDegraded  HealthState = "degraded"
+	HealthUnhealthy HealthState = "unhealthy"
+)
+
+// Health represents the overall health of the runner.
+type Health struct {
+	// State is the worst state given by the health rules.
+	State HealthState `json:"state"`
+
+	// Reasons are the reasons for which the runner is not healthy.
+	Reasons []string `json:"reasons,omitempty"`
 }
 
 // ServiceStatus represents the available information of the service.
 type ServiceInfo struct {
-	Status   ServiceStatus `json:"status"`
-	Uptime   time.Duration `json:"uptime"`
-	Restarts int           `json:"restarts"`
+	Status         ServiceStatus          `json:"status"`
+	Uptime         time.Duration          `json:"uptime"`
+	Restarts       int                    `json:"restarts"`
+	NextTransition *time.Time             `json:"nextTransition,omitempty"`
+	Result         interface{}            `json:"result,omitempty"`
+	Runs           *RunStats              `json:"runs,omitempty"`
+	History        []Transition           `json:"history,omitempty"`
+	Reload         *ReloadResult          `json:"reload,omitempty"`
+	Critical       bool                   `json:"critical,omitempty"`
+	Ready          bool                   `json:"ready,omitempty"`
+	Check          *HealthCheckResult     `json:"check,omitempty"`
+	Details        map[string]interface{} `json:"details,omitempty"`
+
+	ServiceMetadata
+	ServiceStats
+}
+
+// ServiceMetadata represents the descriptive information of a service, given in its options.
+type ServiceMetadata struct {
+	Description string            `json:"description,omitempty"`
+	Owner       string            `json:"owner,omitempty"`
+	Version     string            `json:"version,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+// ServiceStats represents the counters and the timings of the runs of a service,
+// since the service is registered or the runner is booted up again.
+type ServiceStats struct {
+	// Starts is the number of times the service is started.
+	Starts int `json:"starts"`
+
+	// Stops is the number of times the service has stopped running, for any reason.
+	Stops int `json:"stops"`
+
+	// LastStart is the time at which the service is last started.
+	LastStart *time.Time `json:"lastStart,omitempty"`
+
+	// LastExit is the time at which the service has last stopped running.
+	LastExit *time.Time `json:"lastExit,omitempty"`
+
+	// LastExitReason is the reason for which the service has last stopped running.
+	LastExitReason string `json:"lastExitReason,omitempty"`
+
+	// LastError is the error of the last run, empty if the last run has not failed.
+	LastError string `json:"lastError,omitempty"`
+
+	// TotalUptime is the cumulative running time of the service, including the current run.
+	TotalUptime time.Duration `json:"totalUptime"`
+
+	// Downtime is the time for which the service has not been running.
+	Downtime time.Duration `json:"downtime"`
+
+	// Availability is the percentage of the time for which the service has been running.
+	Availability float64 `json:"availability"`
+}
+
+// HealthCheckResult represents the outcome of the last health check of a service.
+type HealthCheckResult struct {
+	// Time is the time at which the service is checked.
+	Time time.Time `json:"time"`
+
+	// Error is the error of the health check, empty if the service is healthy.
+	Error string `json:"error,omitempty"`
+}
+
+// ReloadResult represents the outcome of the last reload of a service.
+type ReloadResult struct {
+	// Time is the time at which the service is reloaded.
+	Time time.Time `json:"time"`
+
+	// Error is the error of the reload (and the fallback restart), empty if the reload has succeeded.
+	Error string `json:"error,omitempty"`
+
+	// Restarted represents if the service is restarted as a fallback for the failed reload.
+	Restarted bool `json:"restarted,omitempty"`
+}
+
+// RunStats represents the statistics of the runs of a periodic service.
+type RunStats struct {
+	Count         int           `json:"count"`
+	Skipped       int           `json:"skipped"`
+	Queued        int           `json:"queued"`
+	Terminated    int           `json:"terminated"`
+	Missed        int           `json:"missed"`
+	CaughtUp      int           `json:"caughtUp"`
+	Running       bool          `json:"running"`
+	LastStart     time.Time     `json:"lastStart"`
+	LastDuration  time.Duration `json:"lastDuration"`
+	TotalDuration time.Duration `json:"totalDuration"`
+	LastRun       time.Time     `json:"lastRun"`
+	NextRun       time.Time     `json:"nextRun"`
 }
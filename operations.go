@@ -0,0 +1,177 @@
+package glcm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// maxOperations is the number of the recent operations kept in the operations table.
+const maxOperations = 100
+
+// operations keeps a bounded table of the recent asynchronous operations.
+type operations struct {
+	// mu is a mutex to protect the operations.
+	mu *sync.Mutex
+
+	// ops are the operations by id.
+	ops map[string]*operation
+
+	// order is the ids of the operations, oldest first.
+	order []string
+
+	// size is the maximum number of operations kept in the table.
+	size int
+
+	// clock is the source of time for the start and end times.
+	clock Clock
+}
+
+// operation is an entry of the operations table.
+type operation struct {
+	op Operation
+
+	// done is closed when the operation is finished.
+	done chan struct{}
+}
+
+// newOperations returns a new instance of the operations table.
+func newOperations(size int, clock Clock) *operations {
+	return &operations{
+		mu:    &sync.Mutex{},
+		ops:   make(map[string]*operation),
+		size:  size,
+		clock: clock,
+	}
+}
+
+// newOperationID returns a new random operation id.
+func (o *operations) newOperationID() string {
+	b := make([]byte, 8)
+
+	if _, err := rand.Read(b); err != nil {
+		// the id is only required to be unique, the time is good enough as a fallback.
+		return strconv.FormatInt(o.clock.Now().UnixNano(), 16)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// submit records a new operation and runs it in the background. It returns the id of the operation.
+// The done message is reported for the services on which the operation has succeeded.
+func (o *operations) submit(action, done string, names []string, run func() ServiceResults) string {
+	e := &operation{
+		done: make(chan struct{}),
+		op: Operation{
+			ID:        o.newOperationID(),
+			Action:    action,
+			Services:  append([]string(nil), names...),
+			State:     OperationRunning,
+			StartTime: o.clock.Now(),
+		},
+	}
+
+	o.mu.Lock()
+
+	o.ops[e.op.ID] = e
+	o.order = append(o.order, e.op.ID)
+
+	o.evict()
+
+	o.mu.Unlock()
+
+	go func() {
+		res := run()
+
+		o.finish(e, res, done)
+	}()
+
+	return e.op.ID
+}
+
+// evict removes the oldest operations beyond the size of the table.
+// The finished operations are removed first. The lock is expected to be held.
+func (o *operations) evict() {
+	for len(o.order) > o.size {
+		victim := 0
+
+		for i, id := range o.order {
+			if o.ops[id].op.State != OperationRunning {
+				victim = i
+
+				break
+			}
+		}
+
+		delete(o.ops, o.order[victim])
+
+		o.order = append(o.order[:victim], o.order[victim+1:]...)
+	}
+}
+
+// finish records the outcome of the operation.
+func (o *operations) finish(e *operation, res ServiceResults, done string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	outcomes, ok := serviceOutcomes(res, done)
+
+	e.op.State = OperationSucceeded
+	if !ok {
+		e.op.State = OperationFailed
+	}
+
+	e.op.Results = outcomes
+	e.op.EndTime = o.clock.Now()
+	e.op.err = res.Err()
+
+	close(e.done)
+}
+
+// get returns a copy of the operation with the given id.
+func (o *operations) get(id string) (*Operation, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	e, ok := o.ops[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrOperationNotFound, id)
+	}
+
+	op := e.op
+
+	return &op, nil
+}
+
+// wait blocks till the operation with the given id is finished, or the context is done.
+// It returns the operation as of the time it returns.
+func (o *operations) wait(ctx context.Context, id string) (*Operation, error) {
+	o.mu.Lock()
+	e, ok := o.ops[id]
+	o.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrOperationNotFound, id)
+	}
+
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		op, err := o.get(id)
+		if err != nil {
+			return nil, err
+		}
+
+		return op, fmt.Errorf("operation %s is %s: %w", id, op.State, ctx.Err())
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	op := e.op
+
+	return &op, nil
+}
@@ -0,0 +1,111 @@
+package glcm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperations(t *testing.T) {
+	tests := []struct {
+		name      string
+		res       ServiceResults
+		wantState OperationState
+		wantErr   bool
+	}{
+		{
+			name:      "Operation succeeded",
+			res:       ServiceResults{"svc1": nil, "svc2": nil},
+			wantState: OperationSucceeded,
+			wantErr:   false,
+		},
+		{
+			name:      "Operation partially failed",
+			res:       ServiceResults{"svc1": nil, "svc2": ErrServiceNotFound},
+			wantState: OperationFailed,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOperations(10, realClock{})
+			release := make(chan struct{})
+
+			id := o.submit("stop", "stopped", []string{"svc1", "svc2"}, func() ServiceResults {
+				<-release
+
+				return tt.res
+			})
+
+			op, err := o.get(id)
+			assert.Nil(t, err, "Expected the submitted operation")
+			assert.Equal(t, OperationRunning, op.State, "Expected the operation to be running")
+			assert.Equal(t, []string{"svc1", "svc2"}, op.Services, "Expected the services of the operation")
+
+			close(release)
+
+			op, err = o.wait(context.Background(), id)
+			assert.Nil(t, err, "Expected no error waiting for the operation")
+			assert.Equal(t, tt.wantState, op.State, "Unexpected state of the operation")
+			assert.Equal(t, tt.wantErr, op.Err() != nil, "Unexpected error of the operation")
+			assert.Equal(t, ServiceOutcome{Result: "stopped", Status: Success}, op.Results["svc1"], "Expected the done message")
+			assert.False(t, op.EndTime.Before(op.StartTime), "Expected the end time to be recorded")
+		})
+	}
+}
+
+func TestOperationsWait(t *testing.T) {
+	o := newOperations(10, realClock{})
+	release := make(chan struct{})
+
+	defer close(release)
+
+	id := o.submit("restart", "restarted", []string{"svc1"}, func() ServiceResults {
+		<-release
+
+		return ServiceResults{"svc1": nil}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	op, err := o.wait(ctx, id)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Expected error for the running operation")
+	assert.Equal(t, OperationRunning, op.State, "Expected the running operation")
+
+	_, err = o.wait(context.Background(), "unknown")
+	assert.True(t, errors.Is(err, ErrOperationNotFound), "Expected error for the unknown operation")
+}
+
+func TestOperationsEvict(t *testing.T) {
+	o := newOperations(2, realClock{})
+	release := make(chan struct{})
+
+	defer close(release)
+
+	running := o.submit("stop", "stopped", nil, func() ServiceResults {
+		<-release
+
+		return nil
+	})
+
+	finished := o.submit("stop", "stopped", nil, func() ServiceResults { return nil })
+
+	_, err := o.wait(context.Background(), finished)
+	assert.Nil(t, err, "Expected the operation to finish")
+
+	// the finished operation is evicted before the older running one.
+	latest := o.submit("stop", "stopped", nil, func() ServiceResults { return nil })
+
+	_, err = o.get(finished)
+	assert.ErrorIs(t, err, ErrOperationNotFound, "Expected the finished operation to be evicted")
+
+	for _, id := range []string{running, latest} {
+		_, err = o.get(id)
+		assert.Nil(t, err, "Expected the operation %s to be kept", id)
+	}
+}
@@ -0,0 +1,446 @@
+package glcm
+
+import (
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/achu-1612/glcm/log"
+)
+
+// maxCountedMissedRuns is the limit for counting the missed runs after a long down time.
+const maxCountedMissedRuns = 10000
+
+// run represents a single run of a periodic service.
+// It implements the Terminator interface for the run.
+type run struct {
+	// tc is closed when the run is to be terminated.
+	tc chan struct{}
+
+	// done is closed when the run is finished.
+	done chan struct{}
+
+	// once guards the closing of the termination channel.
+	once *sync.Once
+
+	// start is the time when the run is started.
+	start time.Time
+
+	// subscribe subscribes to the process signals of the service. nil means no signal is delivered.
+	subscribe func(...os.Signal) <-chan os.Signal
+}
+
+// TermCh returns the termination channel for the run.
+func (r *run) TermCh() chan struct{} {
+	return r.tc
+}
+
+// Signals subscribes the service to the given process signals.
+// The runs of a periodic service share the subscription of the service.
+func (r *run) Signals(sig ...os.Signal) <-chan os.Signal {
+	if r.subscribe == nil {
+		return make(chan os.Signal)
+	}
+
+	return r.subscribe(sig...)
+}
+
+// cancel directs the run to stop, without waiting for it.
+func (r *run) cancel() {
+	r.once.Do(func() {
+		close(r.tc)
+	})
+}
+
+// terminate directs the run to stop and waits for it to finish.
+func (r *run) terminate() {
+	r.cancel()
+
+	<-r.done
+}
+
+// periodic drives the periodic (interval or cron based) runs of a service and records their statistics.
+type periodic struct {
+	// initial returns the time of the first run, for the given loop start time.
+	initial func(time.Time) time.Time
+
+	// next returns the time of the next run after the given time.
+	next func(time.Time) time.Time
+
+	// overlap is the policy when a run is due while the previous run is still going.
+	overlap OverlapPolicy
+
+	// timeout is the maximum duration of a run. 0 means no timeout.
+	timeout time.Duration
+
+	// maxRuns is the maximum number of runs. 0 means unlimited.
+	maxRuns int
+
+	// cron represents if the runs are scheduled by a cron expression. The missed runs are only counted for cron.
+	cron bool
+
+	// missed is the policy for the runs missed while the service was down.
+	missed MissedRunPolicy
+
+	// maxMissed is the maximum number of missed runs to catch up with the all policy.
+	maxMissed int
+
+	// persist stores the schedule state, when the loop starts and on every run. nil means the state is not persisted.
+	persist func(ScheduleState)
+
+	// subscribe subscribes the runs to the process signals of the service. nil means no signal is delivered.
+	subscribe func(...os.Signal) <-chan os.Signal
+
+	// mu is a mutex to protect the run statistics.
+	mu *sync.Mutex
+
+	// stats are the statistics of the runs.
+	stats RunStats
+
+	// clock is the source of time for the runs.
+	clock Clock
+}
+
+// newPeriodic returns a new instance of the interval based periodic driver.
+// It returns nil if the periodic runs are not enabled.
+func newPeriodic(opts PeriodicOptions, clock Clock) *periodic {
+	if opts.Interval <= 0 {
+		return nil
+	}
+
+	interval := func(t time.Time) time.Time {
+		d := opts.Interval
+
+		if opts.Jitter > 0 {
+			d += time.Duration(rand.Int63n(int64(opts.Jitter))) //nolint:gosec // jitter does not need a secure source.
+		}
+
+		return t.Add(d)
+	}
+
+	return &periodic{
+		initial: func(t time.Time) time.Time { return t.Add(opts.InitialDelay) },
+		next:    interval,
+		overlap: opts.Overlap,
+		mu:      &sync.Mutex{},
+		clock:   clock,
+	}
+}
+
+// newScheduled returns a new instance of the cron based periodic driver.
+// It returns nil if the scheduling is not enabled.
+func newScheduled(opts SchedulingOptions, clock Clock) (*periodic, error) {
+	if !opts.Enabled {
+		return nil, nil
+	}
+
+	c, err := parseCron(opts.Cron)
+	if err != nil {
+		return nil, err
+	}
+
+	return &periodic{
+		initial:   c.next,
+		next:      c.next,
+		overlap:   OverlapSkip,
+		cron:      true,
+		timeout:   opts.TimeOut,
+		maxRuns:   opts.MaxRuns,
+		missed:    opts.MissedRuns,
+		maxMissed: opts.MaxMissedRuns,
+		mu:        &sync.Mutex{},
+		clock:     clock,
+	}, nil
+}
+
+// Stats returns a copy of the run statistics.
+func (p *periodic) Stats() *RunStats {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := p.stats
+
+	return &stats
+}
+
+// restore sets the last and next run from the persisted schedule state.
+func (p *periodic) restore(st ScheduleState) {
+	p.record(func(s *RunStats) {
+		s.LastRun = st.LastRun
+		s.NextRun = st.NextRun
+	})
+}
+
+// reset clears the run statistics, so that the maximum number of runs is counted again.
+// The last and the next run are kept, so that the runs missed while the runner was down are still known.
+func (p *periodic) reset() {
+	if p == nil {
+		return
+	}
+
+	p.record(func(s *RunStats) {
+		*s = RunStats{LastRun: s.LastRun, NextRun: s.NextRun}
+	})
+}
+
+// record updates the statistics with the given function.
+func (p *periodic) record(f func(*RunStats)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f(&p.stats)
+}
+
+// save persists the current schedule state.
+func (p *periodic) save() {
+	if p.persist == nil {
+		return
+	}
+
+	st := p.Stats()
+
+	p.persist(ScheduleState{LastRun: st.LastRun, NextRun: st.NextRun})
+}
+
+// catchUp returns the number of runs to be made for the runs missed till now.
+// The missed-run policy only applies to the cron schedules, an interval service just runs on its next interval.
+func (p *periodic) catchUp(now time.Time) int {
+	last := p.Stats().LastRun
+	if !p.cron || last.IsZero() {
+		return 0
+	}
+
+	missed := 0
+
+	for t := p.next(last); !t.IsZero() && !t.After(now) && missed < maxCountedMissedRuns; t = p.next(t) {
+		missed++
+	}
+
+	if missed == 0 {
+		return 0
+	}
+
+	p.record(func(s *RunStats) { s.Missed += missed })
+
+	switch p.missed {
+	case MissedRunOnce:
+		missed = 1
+	case MissedRunAll:
+		missed = min(missed, p.maxMissed)
+	default:
+		missed = 0
+	}
+
+	log.Infof("Catching up with %d missed run(s) ...", missed)
+
+	return missed
+}
+
+// start starts a new run of the service, scheduled for the given time.
+func (p *periodic) start(s Service, slot time.Time) *run {
+	r := &run{
+		tc:        make(chan struct{}),
+		done:      make(chan struct{}),
+		once:      &sync.Once{},
+		start:     p.clock.Now(),
+		subscribe: p.subscribe,
+	}
+
+	p.record(func(st *RunStats) {
+		st.Count++
+		st.Running = true
+		st.LastStart = r.start
+		st.LastRun = slot
+	})
+
+	p.save()
+
+	go func() {
+		defer close(r.done)
+
+		s.Start(r)
+
+		d := p.clock.Since(r.start)
+
+		p.record(func(st *RunStats) {
+			st.Running = false
+			st.LastDuration = d
+			st.TotalDuration += d
+		})
+	}()
+
+	if p.timeout > 0 {
+		go func() {
+			select {
+			case <-p.clock.After(p.timeout):
+				log.Infof("Run of service %s timed out after %s. Terminating it ...", s.Name(), p.timeout)
+
+				r.cancel()
+			case <-r.done:
+			}
+		}()
+	}
+
+	return r
+}
+
+// exhausted returns true if the maximum number of runs has been made.
+func (p *periodic) exhausted() bool {
+	return p.maxRuns > 0 && p.Stats().Count >= p.maxRuns
+}
+
+// loop runs the service periodically till the termination channel is closed.
+// The current run is terminated before returning.
+// It returns true if the loop is finished as the maximum number of runs has been made.
+func (p *periodic) loop(s Service, tc <-chan struct{}) bool {
+	// a service which has already made its runs (e.g. restarted after completion) is finished right away.
+	if p.exhausted() {
+		return true
+	}
+
+	now := p.clock.Now()
+
+	// catching is the number of missed runs to be made, one after the other.
+	catching := p.catchUp(now)
+
+	// pending is the number of queued runs to be started once the current run finishes.
+	pending := 0
+
+	slot := p.initial(now)
+
+	p.record(func(st *RunStats) { st.NextRun = slot })
+
+	// the next run is persisted right away, in case the runner goes down before the first run.
+	p.save()
+
+	var (
+		timer Timer
+
+		// timerC is the channel of the timer. nil (blocks forever) when there is no next run.
+		timerC <-chan time.Time
+	)
+
+	// arm (re-)arms the timer for the next slot.
+	// There is no next run for a schedule which never matches, so the timer is not armed at all.
+	arm := func() {
+		if slot.IsZero() {
+			timerC = nil
+
+			return
+		}
+
+		if timer == nil {
+			timer = p.clock.NewTimer(p.clock.Until(slot))
+		} else {
+			timer.Reset(p.clock.Until(slot))
+		}
+
+		timerC = timer.C()
+	}
+
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	arm()
+
+	var current *run
+
+	// catchUp starts the next missed run.
+	catchUp := func() {
+		catching--
+
+		p.record(func(st *RunStats) { st.CaughtUp++ })
+
+		current = p.start(s, p.clock.Now())
+	}
+
+	if catching > 0 {
+		catchUp()
+	}
+
+	for {
+		// a nil channel blocks forever, when there is no current run.
+		var runDone chan struct{}
+		if current != nil {
+			runDone = current.done
+		}
+
+		select {
+		case <-tc:
+			if current != nil {
+				current.terminate()
+			}
+
+			return false
+
+		case <-runDone:
+			current = nil
+
+			if p.exhausted() {
+				log.Infof("Service %s reached the maximum number of runs", s.Name())
+
+				return true
+			}
+
+			switch {
+			case catching > 0:
+				catchUp()
+			case pending > 0:
+				pending--
+				current = p.start(s, p.clock.Now())
+			}
+
+		case <-timerC:
+			due := slot
+
+			slot = p.next(p.clock.Now())
+
+			p.record(func(st *RunStats) { st.NextRun = slot })
+
+			arm()
+
+			if p.exhausted() {
+				continue
+			}
+
+			if current != nil {
+				switch p.overlap {
+				case OverlapQueue:
+					log.Infof("Previous run of service %s is still going. Queuing the run ...", s.Name())
+
+					if pending == 0 {
+						pending++
+
+						p.record(func(st *RunStats) { st.Queued++ })
+					}
+
+					continue
+
+				case OverlapTerminate:
+					log.Infof("Previous run of service %s is still going. Terminating it ...", s.Name())
+
+					current.terminate()
+
+					p.record(func(st *RunStats) { st.Terminated++ })
+
+				default:
+					log.Infof("Previous run of service %s is still going. Skipping the run ...", s.Name())
+
+					p.record(func(st *RunStats) { st.Skipped++ })
+
+					continue
+				}
+			}
+
+			current = p.start(s, due)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package glcm
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPeriodic(t *testing.T) {
+	assert.Nil(t, newPeriodic(PeriodicOptions{}, realClock{}), "Expected no periodic driver without interval")
+	assert.Nil(t, newPeriodic(PeriodicOptions{}, realClock{}).Stats(), "Expected no stats without periodic driver")
+	assert.NotNil(t, newPeriodic(PeriodicOptions{Interval: time.Second}, realClock{}), "Expected periodic driver with interval")
+}
+
+func TestPeriodicLoop(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     PeriodicOptions
+		runTime  time.Duration
+		validate func(t *testing.T, stats *RunStats, terminated int32)
+	}{
+		{
+			name:    "Runs every interval",
+			opts:    PeriodicOptions{Interval: time.Millisecond * 50},
+			runTime: 0,
+			validate: func(t *testing.T, stats *RunStats, terminated int32) {
+				assert.GreaterOrEqual(t, stats.Count, 4, "Expected the service to run every interval")
+				assert.Zero(t, stats.Skipped, "Expected no skipped runs")
+			},
+		},
+		{
+			name:    "Skips overlapping runs",
+			opts:    PeriodicOptions{Interval: time.Millisecond * 50, Overlap: OverlapSkip},
+			runTime: time.Millisecond * 120,
+			validate: func(t *testing.T, stats *RunStats, terminated int32) {
+				assert.Greater(t, stats.Skipped, 0, "Expected skipped runs")
+				assert.Less(t, stats.Count, 5, "Expected fewer runs due to skipping")
+			},
+		},
+		{
+			name:    "Queues overlapping runs",
+			opts:    PeriodicOptions{Interval: time.Millisecond * 50, Overlap: OverlapQueue},
+			runTime: time.Millisecond * 70,
+			validate: func(t *testing.T, stats *RunStats, terminated int32) {
+				assert.Greater(t, stats.Queued, 0, "Expected queued runs")
+				assert.Zero(t, stats.Skipped, "Expected no skipped runs")
+			},
+		},
+		{
+			name:    "Terminates overlapping runs",
+			opts:    PeriodicOptions{Interval: time.Millisecond * 50, Overlap: OverlapTerminate},
+			runTime: time.Second,
+			validate: func(t *testing.T, stats *RunStats, terminated int32) {
+				assert.Greater(t, stats.Terminated, 0, "Expected terminated runs")
+				assert.GreaterOrEqual(t, int(terminated), stats.Terminated, "Expected the runs to see the termination")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPeriodic(tt.opts, realClock{})
+			svc := &periodicService{runTime: tt.runTime}
+			tc := make(chan struct{})
+			exited := make(chan struct{})
+
+			go func() {
+				p.loop(svc, tc)
+				close(exited)
+			}()
+
+			<-time.After(time.Millisecond * 260)
+
+			close(tc)
+
+			select {
+			case <-exited:
+			case <-time.After(time.Second * 2):
+				t.Fatalf("Expected the periodic loop to exit")
+			}
+
+			stats := p.Stats()
+
+			assert.False(t, stats.Running, "Expected no run to be going after the loop exits")
+			assert.False(t, stats.LastStart.IsZero(), "Expected last start to be recorded")
+
+			tt.validate(t, stats, svc.terminated.Load())
+		})
+	}
+}
+
+type periodicService struct {
+	runTime    time.Duration
+	terminated atomic.Int32
+}
+
+func (p *periodicService) Start(t Terminator) {
+	select {
+	case <-time.After(p.runTime):
+	case <-t.TermCh():
+		p.terminated.Add(1)
+	}
+}
+
+func (p *periodicService) Name() string {
+	return "periodicService"
+}
+
+func TestPeriodicCatchUp(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	hourly := func(t time.Time) time.Time { return t.Truncate(time.Hour).Add(time.Hour) }
+
+	tests := []struct {
+		name       string
+		interval   bool
+		lastRun    time.Time
+		policy     MissedRunPolicy
+		maxMissed  int
+		wantRuns   int
+		wantMissed int
+	}{
+		{
+			name:       "No previous run",
+			lastRun:    time.Time{},
+			policy:     MissedRunAll,
+			maxMissed:  10,
+			wantRuns:   0,
+			wantMissed: 0,
+		},
+		{
+			name:       "Nothing missed",
+			lastRun:    now.Add(-time.Minute * 30),
+			policy:     MissedRunAll,
+			maxMissed:  10,
+			wantRuns:   0,
+			wantMissed: 0,
+		},
+		{
+			name:       "Skip missed runs",
+			lastRun:    now.Add(-time.Hour * 5),
+			policy:     MissedRunSkip,
+			wantRuns:   0,
+			wantMissed: 5,
+		},
+		{
+			name:       "Run once for missed runs",
+			lastRun:    now.Add(-time.Hour * 5),
+			policy:     MissedRunOnce,
+			wantRuns:   1,
+			wantMissed: 5,
+		},
+		{
+			name:       "Run all missed runs up to the limit",
+			lastRun:    now.Add(-time.Hour * 5),
+			policy:     MissedRunAll,
+			maxMissed:  3,
+			wantRuns:   3,
+			wantMissed: 5,
+		},
+		{
+			name:       "Interval service",
+			interval:   true,
+			lastRun:    now.Add(-time.Hour * 5),
+			wantRuns:   0,
+			wantMissed: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &periodic{
+				next:      hourly,
+				cron:      !tt.interval,
+				missed:    tt.policy,
+				maxMissed: tt.maxMissed,
+				mu:        &sync.Mutex{},
+				clock:     realClock{},
+			}
+
+			p.restore(ScheduleState{LastRun: tt.lastRun})
+
+			assert.Equal(t, tt.wantRuns, p.catchUp(now), "Unexpected number of catch up runs")
+			assert.Equal(t, tt.wantMissed, p.Stats().Missed, "Unexpected number of missed runs")
+		})
+	}
+}
+
+func TestPeriodicLoopCatchUpAndMaxRuns(t *testing.T) {
+	var persisted []ScheduleState
+
+	p := &periodic{
+		initial:   func(t time.Time) time.Time { return t.Add(time.Hour) },
+		next:      func(t time.Time) time.Time { return t.Add(time.Minute) },
+		cron:      true,
+		missed:    MissedRunAll,
+		maxMissed: 10,
+		maxRuns:   3,
+		persist:   func(st ScheduleState) { persisted = append(persisted, st) },
+		mu:        &sync.Mutex{},
+		clock:     realClock{},
+	}
+
+	// 5 runs were missed, 3 of them are made before the maximum runs are reached.
+	p.restore(ScheduleState{LastRun: time.Now().Add(-time.Minute * 5)})
+
+	finished := make(chan bool)
+
+	go func() {
+		finished <- p.loop(&periodicService{}, make(chan struct{}))
+	}()
+
+	select {
+	case ok := <-finished:
+		assert.True(t, ok, "Expected the loop to finish after the maximum runs")
+	case <-time.After(time.Second * 2):
+		t.Fatalf("Expected the loop to finish after the maximum runs")
+	}
+
+	stats := p.Stats()
+
+	assert.Equal(t, 3, stats.Count, "Expected the maximum number of runs")
+	assert.Equal(t, 3, stats.CaughtUp, "Expected the missed runs to be caught up")
+	assert.Len(t, persisted, 4, "Expected the state to be persisted on the start of the loop and on every run")
+	assert.False(t, persisted[0].NextRun.IsZero(), "Expected the next run to be persisted before the first run")
+	assert.False(t, stats.NextRun.IsZero(), "Expected the next run to be recorded")
+}
+
+func TestPeriodicLoopNeverMatches(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	never := func(time.Time) time.Time { return time.Time{} }
+
+	p := &periodic{
+		initial: never,
+		next:    never,
+		mu:      &sync.Mutex{},
+		clock:   clock,
+	}
+
+	tc := make(chan struct{})
+	exited := make(chan struct{})
+
+	go func() {
+		p.loop(&periodicService{}, tc)
+		close(exited)
+	}()
+
+	<-time.After(time.Millisecond * 100)
+
+	assert.Zero(t, clock.Timers(), "Expected no timer for a schedule which never matches")
+	assert.Zero(t, p.Stats().Count, "Expected no run for a schedule which never matches")
+
+	close(tc)
+
+	select {
+	case <-exited:
+	case <-time.After(time.Second * 2):
+		t.Fatalf("Expected the periodic loop to exit")
+	}
+}
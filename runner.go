@@ -2,17 +2,22 @@ package glcm
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"math"
 	"os"
-	"os/signal"
+	"sort"
 	"sync"
-	"syscall"
+	"sync/atomic"
 	"time"
 
 	"github.com/achu-1612/glcm/log"
 )
 
+// conditionRecheckInterval is the interval at which the unmet conditions of a service are re-checked.
+const conditionRecheckInterval = time.Second
+
 // runner implements the Base interface.
 type runner struct {
 	// swg is a wait group to wait for all the services to stop.
@@ -27,6 +32,22 @@ type runner struct {
 	// isRunning is a flag to indicate if the runner is running or not.
 	isRunning bool
 
+	// done is closed by the shutdown of the current boot up. It is closed, if the runner is not running.
+	done chan struct{}
+
+	// reasons are the reasons for which the current boot up has stopped.
+	reasons []error
+
+	// signals represents the handling of the OS signals.
+	signals SignalOptions
+
+	// sigs is the channel of the OS signals of the current boot up. nil if the signals are not handled.
+	sigs chan os.Signal
+
+	// shuttingDown is a flag to indicate if the runner is shutting down.
+	// The services are not reconciled while shutting down, so that they are not started again.
+	shuttingDown bool
+
 	// ctx is the base context for the runner.
 	ctx context.Context
 
@@ -44,6 +65,33 @@ type runner struct {
 
 	// shutdownTimeout represents the timeout for shutting down the runner.
 	shutdownTimeout time.Duration
+
+	// store persists the schedule state of the services. nil if the state is kept in memory only.
+	store *stateStore
+
+	// gate limits the concurrent starts and staggers the starts of the services.
+	gate *startGate
+
+	// events holds the services to be reconciled, pushed on their status changes.
+	events *eventQueue
+
+	// resyncInterval represents the interval at which all the services are reconciled.
+	resyncInterval time.Duration
+
+	// clock is the source of time for the runner and the services.
+	clock Clock
+
+	// changes wakes up the waiters on every change of the runner or service status.
+	changes *broadcaster
+
+	// ops keeps the recent asynchronous operations.
+	ops *operations
+
+	// health represents the options for computing the health of the runner.
+	health HealthOptions
+
+	// checking is a flag to indicate if a round of health checks is going.
+	checking atomic.Bool
 }
 
 // NewRunner returns a new instance of the runner.
@@ -59,8 +107,20 @@ func NewRunner(ctx context.Context, opts RunnerOptions) Runner {
 		socketPath:      opts.SocketPath,
 		allowedUIDs:     opts.AllowedUID,
 		shutdownTimeout: opts.ShutdownTimeout,
+		gate:            newStartGate(opts.MaxConcurrentStarts, opts.StartStagger, opts.Clock),
+		events:          newEventQueue(opts.Clock),
+		resyncInterval:  opts.ResyncInterval,
+		clock:           opts.Clock,
+		changes:         newBroadcaster(),
+		ops:             newOperations(maxOperations, opts.Clock),
+		done:            make(chan struct{}),
+		signals:         opts.Signals,
+		health:          opts.Health,
 	}
 
+	// the runner is not running till it is booted up.
+	close(r.done)
+
 	if opts.Verbose {
 		log.SetOutput(io.Discard)
 	}
@@ -71,6 +131,15 @@ func NewRunner(ctx context.Context, opts RunnerOptions) Runner {
 		r.ctx = context.Background()
 	}
 
+	if opts.StateFile != "" {
+		store, err := newStateStore(opts.StateFile)
+		if err != nil {
+			log.Errorf("loading the state file: %v", err)
+		}
+
+		r.store = store
+	}
+
 	if opts.Socket {
 		socket, err := newSocket(r, opts.SocketPath, opts.AllowedUID)
 		if err != nil {
@@ -108,7 +177,49 @@ func (r *runner) RegisterService(svc Service, opts ServiceOptions) error {
 
 	opts.Sanitize()
 
-	r.svc[sName] = NewWrapper(svc, r.swg, opts)
+	if opts.Schedule.Enabled {
+		if _, err := parseCron(opts.Schedule.Cron); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+		}
+	}
+
+	if err := validateLabels(opts.Labels); err != nil {
+		return err
+	}
+
+	w := newWrapper(svc, r.swg, opts, r.clock)
+	w.gate = r.gate
+
+	// every status change of the service is reconciled right away.
+	w.state.observer = func(Transition) {
+		r.events.push(sName)
+		r.changes.notify()
+	}
+
+	// the signals subscribed by the service are relayed by the runner.
+	w.onSubscribe = r.subscribeSignals
+
+	// restore the schedule state, so that the runs missed while the runner was down are known.
+	if w.periodic != nil && r.store != nil {
+		if st, ok := r.store.get(sName); ok {
+			w.periodic.restore(st)
+		}
+
+		w.periodic.persist = func(st ScheduleState) {
+			if err := r.store.set(sName, st); err != nil {
+				log.Errorf("persisting schedule state of service %s: %v", sName, err)
+			}
+		}
+	}
+
+	r.svc[sName] = w
+
+	r.changes.notify()
+
+	// a service registered with a running runner is started without waiting for the resync.
+	if r.isRunning {
+		r.events.push(sName)
+	}
 
 	return nil
 }
@@ -116,152 +227,405 @@ func (r *runner) RegisterService(svc Service, opts ServiceOptions) error {
 // DeregisterService deregisters a service from the runner.
 // If the service is running, it will be stopped before deregistering.
 func (r *runner) DeregisterService(name string) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	return r.DeregisterServiceContext(context.Background(), name)[name]
+}
 
-	if _, ok := r.svc[name]; !ok {
-		return ErrDeregisterServiceNotFound
-	}
+// DeregisterServiceContext deregisters the given services from the runner.
+// The running services are stopped before deregistering, a service which does not stop
+// before the context is done is not deregistered.
+// The services are given by their exact names, the patterns and the selectors are not resolved,
+// so that a service is never deregistered by accident.
+func (r *runner) DeregisterServiceContext(ctx context.Context, name ...string) ServiceResults {
+	found, res := r.lookup(name...)
+
+	return r.each(ctx, found, res, func(ctx context.Context, n string, w Wrapper) error {
+		if err := stopWithin(ctx, w); err != nil && !errors.Is(err, ErrServiceNotRunning) {
+			return err
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		// the service might have been replaced while it was stopping.
+		if r.svc[n] == w {
+			delete(r.svc, n)
+
+			r.changes.notify()
+		}
+
+		return nil
+	})
+}
 
-	// stop the service if it is running.
-	if r.svc[name].Status() == ServiceStatusRunning {
-		r.svc[name].Stop()
+// BootUp boots up the runner and blocks till the runner is shut down.
+// The runner can be booted up again after the shutdown, with the same registrations.
+// The idle services (e.g. stopped by the shutdown) are reset and started again on every boot up.
+// It returns an error wrapping ErrCriticalServiceFailed, if a critical service has taken the runner down.
+// Use Start and Wait to know the other reasons for which the runner has stopped.
+func (r *runner) BootUp() error {
+	if err := r.Start(context.Background()); err != nil {
+		return err
 	}
 
-	delete(r.svc, name)
+	if err := r.Wait(); errors.Is(err, ErrCriticalServiceFailed) {
+		return err
+	}
 
 	return nil
 }
 
-// BootUp boots up the runner.
-func (r *runner) BootUp() error {
-	if r.IsRunning() {
+// Start boots up the runner and returns once the services are started (or scheduled to start).
+// The runner is shut down when the given context, or the base context of the runner, is done.
+func (r *runner) Start(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	r.mu.Lock()
+
+	if r.isRunning {
+		r.mu.Unlock()
+
 		return ErrRunnerAlreadyRunning
 	}
 
+	r.isRunning = true
+	r.reasons = nil
+
+	done := make(chan struct{})
+	r.done = done
+
+	r.mu.Unlock()
+
 	if !r.hideBanner {
 		os.Stdout.Write([]byte(banner + "\n"))
 	}
 
 	log.Info("Booting up the Runner ...")
 
-	r.isRunning = true
-
-	quit := make(chan os.Signal, 1)
+	r.changes.notify()
 
-	signal.Notify(quit,
-		syscall.SIGTERM, syscall.SIGINT,
-		syscall.SIGQUIT, syscall.SIGHUP)
+	sigs, stopSignals := r.notifySignals()
 
 	if r.socket != nil {
+		// the channels of the socket are closed by the previous shutdown.
+		r.socket.reset()
+
 		// start the socket inside a go-routine, as Start is a blocking call.,
-		// it will be shutdown automatically when we receive the signal.
+		// it will be shutdown along with the runner.
 		go func() {
 			if err := r.socket.start(); err != nil {
 				log.Errorf("failed to start socket: %v", err)
 			}
 		}()
+	}
 
-		defer r.socket.shutdown()
+	for _, w := range r.services() {
+		if err := w.Reset(); err != nil {
+			log.Warnf("Service %s is not reset for boot up: %v", w.Name(), err)
+		}
 	}
 
-	t := time.NewTicker(time.Second)
+	// the first reconcile starts all the registered services,
+	// which covers the events pushed before booting up.
+	r.events.drain()
+	r.reconcile()
+
+	go func() {
+		defer stopSignals()
+
+		r.loop(ctx, sigs, done)
+	}()
+
+	return nil
+}
+
+// loop reconciles the services till the runner is shut down.
+func (r *runner) loop(ctx context.Context, sigs <-chan os.Signal, done chan struct{}) {
+	// the resync is a safety net, the services are reconciled on their status changes.
+	t := r.clock.NewTicker(r.resyncInterval)
+	defer t.Stop()
+
+	ht := r.clock.NewTicker(r.health.CheckInterval)
+	defer ht.Stop()
 
 	for {
 		select {
-		case <-quit:
-			log.Info("Received shutdown signal. Shutting down the runner ...")
-			r.Shutdown()
-
-			return nil
+		case sig := <-sigs:
+			if r.handleSignal(sig) {
+				return
+			}
+		case sig := <-r.signals.Trigger:
+			if r.handleSignal(sig) {
+				return
+			}
 		case <-r.ctx.Done():
-			log.Info("Received shutdown signal. Shutting down the runner ...")
-			r.Shutdown()
-
-			return nil
-		case <-t.C:
+			log.Info("Base context is done. Shutting down the runner ...")
+			r.stop(fmt.Errorf("base context: %w", context.Cause(r.ctx)))
+
+			return
+		case <-ctx.Done():
+			log.Info("Context is done. Shutting down the runner ...")
+			r.stop(fmt.Errorf("start context: %w", context.Cause(ctx)))
+
+			return
+		case <-done:
+			return
+		case <-r.events.wake:
+			if names := r.events.drain(); len(names) > 0 {
+				r.reconcile(names...)
+			}
+		case <-t.C():
 			r.reconcile()
+		case <-ht.C():
+			go r.checkHealth()
+		}
+	}
+}
+
+// stop records the reason and shuts down the runner.
+// A reason which is already recorded (e.g. a failed service reconciled again) is not repeated.
+func (r *runner) stop(reason error) {
+	r.mu.Lock()
+
+	dup := false
+
+	for _, e := range r.reasons {
+		if e.Error() == reason.Error() {
+			dup = true
 		}
 	}
+
+	if !dup {
+		r.reasons = append(r.reasons, reason)
+	}
+
+	r.mu.Unlock()
+
+	r.Shutdown()
 }
 
-// reconcile takes necessary actions on the services based on their state.
-func (r *runner) reconcile() {
+// Done returns a channel which is closed once the runner is shut down.
+// The channel is closed, if the runner is not running.
+func (r *runner) Done() <-chan struct{} {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	for _, w := range r.svc {
-		log.Infof("Reconciling service: %s, current status: %s", w.Name(), w.Status())
+	return r.done
+}
 
-		// The services are expected to be in the registered state at first.
-		// If the service is registered, then start the service on first rec cycle.
-		if w.Status() == ServiceStatusRegistered {
-			log.Infof("Service %s is registered. Starting service ...", w.Name())
+// Wait blocks till the runner is shut down, and returns the reasons for which it has stopped,
+// i.e. a signal, a done context or a critical service failure. It returns nil for a Shutdown call.
+func (r *runner) Wait() error {
+	<-r.Done()
 
-			go w.Start()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return errors.Join(r.reasons...)
+}
+
+// reconcile takes necessary actions on the given services based on their state.
+// All the services are reconciled, if no service is given.
+func (r *runner) reconcile(names ...string) {
+	r.mu.Lock()
+
+	if r.shuttingDown {
+		r.mu.Unlock()
+
+		return
+	}
+
+	r.mu.Unlock()
+
+	// the services might have been deregistered after the events.
+	for _, w := range r.services(names...) {
+		r.reconcileService(w)
+	}
+}
+
+// services returns a snapshot of the given registered services, or all of them if no service is given.
+// The unknown services are skipped. The services are acted upon outside of the runner mutex,
+// so that a slow service does not block the other operations (e.g. the status) of the runner.
+func (r *runner) services(names ...string) []Wrapper {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(names) == 0 {
+		ws := make([]Wrapper, 0, len(r.svc))
+
+		for _, w := range r.svc {
+			ws = append(ws, w)
 		}
 
-		// skip the service if it is already pending start.
-		if w.AutoRestart().PendingStart.Load() {
-			log.Infof("Service %s is pending start. Skipping ...", w.Name())
+		return ws
+	}
 
-			continue
+	ws := make([]Wrapper, 0, len(names))
+
+	for _, name := range names {
+		if w, ok := r.svc[name]; ok {
+			ws = append(ws, w)
 		}
+	}
 
-		// auto restart the service if it is exited (not stopped) and auto-restart is enabled for the service
-		// the service will not be started automatically if it stopped by the runner.
-		if w.Status() == ServiceStatusExited && w.AutoRestart().Enabled {
-			if w.AutoRestart().RetryCount >= w.AutoRestart().MaxRetries {
-				log.Infof("Service %s reached max retries. Not restarting ...", w.Name())
+	return ws
+}
 
-				continue
-			}
+// reconcileService takes necessary actions on the service based on its state.
+func (r *runner) reconcileService(w Wrapper) {
+	status := w.Status()
 
-			backoffDuration := time.Duration(0)
+	log.Debugf("Reconciling service: %s, current status: %s", w.Name(), status)
 
-			if w.AutoRestart().Backoff {
-				backoffDuration = time.Duration(
-					math.Pow(float64(w.AutoRestart().BackoffExponent), float64(w.AutoRestart().RetryCount)),
-				) * time.Second
-			}
+	switch {
+	// The services are expected to be in the registered state at first.
+	// If the service is registered, then start the service on first rec cycle.
+	case status == ServiceStatusRegistered:
+		log.Infof("Service %s is registered. Starting service ...", w.Name())
+
+		go w.Start()
+
+	// re-check the conditions of the service, the service will be started once they are met.
+	// A re-check which finds the conditions still unmet does not change the status,
+	// so the next re-check is scheduled here.
+	case status == ServiceStatusConditionUnmet:
+		r.events.wakeAt(w.Name(), r.clock.Now().Add(conditionRecheckInterval))
+
+		go w.Start()
 
-			w.AutoRestart().RetryCount++
+	// start the service when its next active window opens.
+	case status == ServiceStatusOutOfWindow:
+		if w.InActiveWindow() {
+			log.Infof("Active window opened for service %s. Starting service ...", w.Name())
 
-			// using same flow for both immediate and backoff restarts.
-			w.AutoRestart().PendingStart.Store(true)
+			go w.Start()
+
+			return
+		}
 
-			go func() {
-				if backoffDuration > 0 {
-					log.Infof("Service %s backing-off. Restarting in %s ...", w.Name(), backoffDuration)
+		r.wakeOnWindow(w)
 
-					<-time.After(backoffDuration)
-				}
+	// stop the service gracefully when its active window closes.
+	case status.active():
+		if !w.InActiveWindow() {
+			log.Infof("Active window closed for service %s. Stopping service ...", w.Name())
 
-				log.Infof("Service %s restarting now ...", w.Name())
+			go w.Stop()
 
-				w.Start()
-			}()
+			return
 		}
+
+		r.wakeOnWindow(w)
+
+	// a critical service which has failed for good takes the runner down.
+	case (status == ServiceStatusExhausted || status == ServiceStatusExited && !w.AutoRestart().Enabled) && w.Critical():
+		log.Errorf("Critical service %s is %s. Shutting down the runner ...", w.Name(), status)
+
+		// the shutdown waits for the services, the reconcile does not.
+		go r.stop(fmt.Errorf("%w: %s is %s", ErrCriticalServiceFailed, w.Name(), status))
+
+	// auto restart the service if it is exited (not stopped) and auto-restart is enabled for the service
+	// the service will not be started automatically if it stopped by the runner.
+	case status == ServiceStatusExited && w.AutoRestart().Enabled:
+		r.scheduleRestart(w)
+	}
+}
+
+// wakeOnWindow schedules the reconcile of the service for its next active window transition.
+func (r *runner) wakeOnWindow(w Wrapper) {
+	if next := w.NextTransition(); !next.IsZero() {
+		r.events.wakeAt(w.Name(), next)
 	}
 }
 
+// scheduleRestart schedules the restart of an exited service after the backoff.
+// The service is marked as exhausted, once it reaches the max retries.
+func (r *runner) scheduleRestart(w Wrapper) {
+	ar := w.AutoRestart()
+	retries := w.Retries()
+
+	if retries >= ar.MaxRetries {
+		log.Infof("Service %s reached max retries. Not restarting ...", w.Name())
+
+		if err := w.Transition(ServiceStatusExhausted, fmt.Sprintf("reached max retries %d", ar.MaxRetries)); err != nil {
+			log.Errorf("Service %s: %v", w.Name(), err)
+		}
+
+		return
+	}
+
+	backoffDuration := time.Duration(0)
+
+	if ar.Backoff {
+		backoffDuration = time.Duration(
+			math.Pow(float64(ar.BackoffExponent), float64(retries)),
+		) * time.Second
+	}
+
+	// using same flow for both immediate and backoff restarts.
+	reason := fmt.Sprintf("restart attempt %d in %s", retries+1, backoffDuration)
+
+	if err := w.Transition(ServiceStatusScheduledForRestart, reason); err != nil {
+		log.Errorf("Service %s: %v", w.Name(), err)
+
+		return
+	}
+
+	w.IncRetry()
+
+	r.changes.notify()
+
+	go func() {
+		if backoffDuration > 0 {
+			log.Infof("Service %s backing-off. Restarting in %s ...", w.Name(), backoffDuration)
+
+			<-r.clock.After(backoffDuration)
+		}
+
+		// the service might have been stopped while backing-off.
+		if w.Status() != ServiceStatusScheduledForRestart {
+			log.Infof("Service %s is %s. Not restarting ...", w.Name(), w.Status())
+
+			return
+		}
+
+		log.Infof("Service %s restarting now ...", w.Name())
+
+		w.Start()
+	}()
+}
+
 // Shutdown shuts down the runner. This will stop all the registered services.
 func (r *runner) Shutdown() {
 	r.mu.Lock()
-	defer r.mu.Unlock()
 
 	if !r.isRunning {
 		log.Warn("Runner is not running. Skipping shutdown ...")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), r.shutdownTimeout)
-	defer cancel()
+	// only the first of the concurrent shutdown calls ends the boot up, the others wait for it.
+	first, booted, done := !r.shuttingDown, r.isRunning, r.done
+
+	r.shuttingDown = true
+
+	r.mu.Unlock()
 
 	log.Info("Shutting down Runner...")
 
-	for _, svc := range r.svc {
-		if svc.Status() == ServiceStatusRunning {
+	svcs := r.services()
+
+	// stopping is a wait group for the stop calls on the services.
+	stopping := &sync.WaitGroup{}
+
+	for _, svc := range svcs {
+		// a service waiting for its restart is stopped too, so that it is not restarted after the stop.
+		if svc.Status().stoppable() {
+			stopping.Add(1)
+
 			go func(svc Wrapper) {
+				defer stopping.Done()
+
 				svc.Stop()
 			}(svc)
 		}
@@ -270,99 +634,414 @@ func (r *runner) Shutdown() {
 	gracefulShutdown := make(chan struct{})
 
 	go func() {
-		log.Infof("Waiting for %d service(s) to stop ...", len(r.svc))
+		log.Infof("Waiting for %d service(s) to stop ...", len(svcs))
 
+		stopping.Wait()
 		r.swg.Wait()
 
 		close(gracefulShutdown)
 	}()
 
 	select {
-	case <-ctx.Done():
+	case <-r.clock.After(r.shutdownTimeout):
 		log.Infof("Graceful shutdown timed out. Forcing shutdown ...")
 	case <-gracefulShutdown:
 		log.Infof("All services stopped gracefully.")
 	}
 
+	if !first {
+		<-done
+
+		return
+	}
+
+	r.events.stop()
+
+	if booted && r.socket != nil {
+		r.socket.shutdown()
+	}
+
+	r.mu.Lock()
+
 	r.isRunning = false
+	r.shuttingDown = false
+
+	r.mu.Unlock()
+
+	// the boot up returns once the runner is not running, so that it can be booted up again right away.
+	if booted {
+		close(done)
+	}
+
+	r.changes.notify()
 }
 
 // StopAllServices stops all the registered/running services.
+// It waits for the stop calls, not for the wait group of the services,
+// as the services might be (re-)started concurrently by the other operations.
 func (r *runner) StopAllServices() {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	stopping := &sync.WaitGroup{}
+
+	for _, svc := range r.services() {
+		if svc.Status().stoppable() {
+			stopping.Add(1)
 
-	for _, svc := range r.svc {
-		if svc.Status() == ServiceStatusRunning {
 			go func(svc Wrapper) {
+				defer stopping.Done()
+
 				svc.Stop()
 			}(svc)
 		}
 	}
 
-	r.swg.Wait()
+	stopping.Wait()
 }
 
 // StopService stops the given list of services.
+// It returns the errors for the services which are not found or not running.
 func (r *runner) StopService(name ...string) error {
+	return r.StopServiceContext(context.Background(), name...).Err()
+}
+
+// StopServiceContext stops the given list of services and returns the outcome for each of them.
+func (r *runner) StopServiceContext(ctx context.Context, name ...string) ServiceResults {
+	return r.forEach(ctx, name, func(ctx context.Context, _ string, w Wrapper) error {
+		return stopWithin(ctx, w)
+	})
+}
+
+// RestartService restarts the given list of services.
+// It returns the errors for the services which are not found or not running.
+func (r *runner) RestartService(name ...string) error {
+	return r.RestartServiceContext(context.Background(), name...).Err()
+}
+
+// RestartServiceContext restarts the given list of services and returns the outcome for each of them.
+// A service is started again once it has stopped.
+func (r *runner) RestartServiceContext(ctx context.Context, name ...string) ServiceResults {
+	return r.forEach(ctx, name, func(ctx context.Context, _ string, w Wrapper) error {
+		if err := stopWithin(ctx, w); err != nil {
+			return err
+		}
+
+		go w.Start()
+
+		return nil
+	})
+}
+
+// ReloadService reloads the given list of services.
+// It returns the errors for the services which are not found, not running or failed to reload.
+func (r *runner) ReloadService(name ...string) error {
+	return r.ReloadServiceContext(context.Background(), name...).Err()
+}
+
+// ReloadServiceContext reloads the given list of services and returns the outcome for each of them.
+// The services which do not implement the Reloader interface fail with ErrReloadNotSupported,
+// unless they fall back to a restart.
+func (r *runner) ReloadServiceContext(ctx context.Context, name ...string) ServiceResults {
+	res := r.forEach(ctx, name, func(ctx context.Context, _ string, w Wrapper) error {
+		return w.Reload(ctx)
+	})
+
+	// the outcomes of the reloads are reported in the status.
+	r.changes.notify()
+
+	return res
+}
+
+// reloadAll reloads all the running services.
+func (r *runner) reloadAll() {
+	var names []string
+
+	for _, w := range r.services() {
+		if w.Status() == ServiceStatusRunning {
+			names = append(names, w.Name())
+		}
+	}
+
+	if err := r.ReloadServiceContext(r.ctx, names...).Err(); err != nil {
+		log.Errorf("Reloading the services: %v", err)
+	}
+}
+
+// StopServiceAsync submits the stop of the given list of services and returns the id of the operation.
+func (r *runner) StopServiceAsync(name ...string) string {
+	return r.ops.submit(string(SocketActionStopService), "stopped", name, func() ServiceResults {
+		return r.StopServiceContext(r.ctx, name...)
+	})
+}
+
+// RestartServiceAsync submits the restart of the given list of services and returns the id of the operation.
+func (r *runner) RestartServiceAsync(name ...string) string {
+	return r.ops.submit(string(SocketActionRestartService), "restarted", name, func() ServiceResults {
+		return r.RestartServiceContext(r.ctx, name...)
+	})
+}
+
+// Operation returns the asynchronous operation with the given id.
+func (r *runner) Operation(id string) (*Operation, error) {
+	return r.ops.get(id)
+}
+
+// WaitOperation blocks till the asynchronous operation with the given id is finished, or the context is done.
+func (r *runner) WaitOperation(ctx context.Context, id string) (*Operation, error) {
+	return r.ops.wait(ctx, id)
+}
+
+// resolve returns the services for the given targets of a control operation, by name.
+// A target is either the name of a service, or a glob pattern (e.g. ingest.*), a regular expression
+// (e.g. re:^ingest\.shard-[0-9]+$) or a label selector (e.g. tier=ingest,env!=canary) which may match any number of services.
+// The errors of the targets which match no service are returned.
+func (r *runner) resolve(targets ...string) (map[string]Wrapper, ServiceResults) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	for _, n := range name {
-		if svc, ok := r.svc[n]; ok && svc.Status() == ServiceStatusRunning {
-			svc.Stop()
+	found := make(map[string]Wrapper, len(targets))
+	res := make(ServiceResults, len(targets))
+
+	for _, t := range targets {
+		// a service name is preferred over a pattern or a selector, in case the name has any of their characters.
+		if w, ok := r.svc[t]; ok {
+			found[t] = w
+
+			continue
+		}
+
+		match, err := parseTarget(t)
+		if err != nil {
+			res[t] = err
+
+			continue
+		}
+
+		if match == nil {
+			res[t] = ErrServiceNotFound
+
+			continue
+		}
+
+		matched := 0
+
+		for name, w := range r.svc {
+			if match(name, w.Metadata().Labels) {
+				found[name] = w
+				matched++
+			}
+		}
+
+		if matched == 0 {
+			res[t] = fmt.Errorf("%w: %s", ErrNoServiceMatched, t)
 		}
 	}
 
-	return nil
+	return found, res
 }
 
-// RestartService restarts the given list of services.
-func (r *runner) RestartService(name ...string) error {
+// lookup returns the services with the given exact names.
+// The names which are not registered are returned with ErrServiceNotFound.
+func (r *runner) lookup(names ...string) (map[string]Wrapper, ServiceResults) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	for _, n := range name {
-		if svc, ok := r.svc[n]; ok {
-			if svc.Status() == ServiceStatusRunning {
-				svc.Stop()
-				go svc.Start()
-			}
+	found := make(map[string]Wrapper, len(names))
+	res := make(ServiceResults, len(names))
+
+	for _, n := range names {
+		if w, ok := r.svc[n]; ok {
+			found[n] = w
+		} else {
+			res[n] = ErrServiceNotFound
 		}
 	}
 
-	return nil
+	return found, res
+}
+
+// Resolve returns the sorted names of the services matched by the given targets,
+// i.e. the service names, the glob patterns, the regular expressions or the label selectors.
+// It returns an error for the targets which match no service.
+func (r *runner) Resolve(targets ...string) ([]string, error) {
+	found, res := r.resolve(targets...)
+
+	names := make([]string, 0, len(found))
+
+	for name := range found {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, res.Err()
+}
+
+// forEach runs the operation on each of the services matched by the targets concurrently and collects the outcomes.
+// The services which are not registered are reported with ErrServiceNotFound,
+// and the patterns or the selectors which match no service with ErrNoServiceMatched.
+func (r *runner) forEach(
+	ctx context.Context,
+	names []string,
+	op func(ctx context.Context, name string, w Wrapper) error,
+) ServiceResults {
+	found, res := r.resolve(names...)
+
+	return r.each(ctx, found, res, op)
+}
+
+// each runs the operation on each of the found services concurrently, and adds the outcomes to the results.
+func (r *runner) each(
+	ctx context.Context,
+	found map[string]Wrapper,
+	res ServiceResults,
+	op func(ctx context.Context, name string, w Wrapper) error,
+) ServiceResults {
+	mu := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+
+	for n, w := range found {
+		wg.Add(1)
+
+		go func(n string, w Wrapper) {
+			defer wg.Done()
+
+			err := op(ctx, n, w)
+
+			mu.Lock()
+			res[n] = err
+			mu.Unlock()
+		}(n, w)
+	}
+
+	wg.Wait()
+
+	return res
+}
+
+// stopWithin stops the service and waits for it to stop, till the context is done.
+// The service keeps stopping in the background, if the context is done first.
+func stopWithin(ctx context.Context, w Wrapper) error {
+	// a service waiting for its restart is stopped too.
+	if status := w.Status(); !status.stoppable() {
+		return fmt.Errorf("%w: %s", ErrServiceNotRunning, status)
+	}
+
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		w.Stop()
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %w", ErrOperationTimeout, ctx.Err())
+	}
 }
 
 // RestartAllServices restarts all the registered/running services.
+// The services are restarted concurrently, and it returns once all of them are stopped.
 func (r *runner) RestartAllServices() {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	wg := &sync.WaitGroup{}
 
-	for _, svc := range r.svc {
+	for _, svc := range r.services() {
 		if svc.Status() == ServiceStatusRunning {
-			svc.Stop()
-			go svc.Start()
+			wg.Add(1)
+
+			go func(svc Wrapper) {
+				defer wg.Done()
+
+				svc.Stop()
+				go svc.Start()
+			}(svc)
 		}
 	}
+
+	wg.Wait()
 }
 
-func (r *runner) Status() *RunnerStatus {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+// WaitForStatus blocks till the given service is in one of the given statuses, or the context is done.
+func (r *runner) WaitForStatus(ctx context.Context, name string, statuses ...ServiceStatus) error {
+	for {
+		// the channel is taken before checking the status, so that no change is missed.
+		changed := r.changes.changed()
+
+		ws := r.services(name)
+		if len(ws) == 0 {
+			return fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+		}
+
+		status := ws[0].Status()
 
+		for _, s := range statuses {
+			if status == s {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("service %s is %s: %w", name, status, ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+// WaitForAll blocks till the given predicate on the runner status is true, or the context is done.
+func (r *runner) WaitForAll(ctx context.Context, predicate func(*RunnerStatus) bool) error {
+	for {
+		// the channel is taken before checking the status, so that no change is missed.
+		changed := r.changes.changed()
+
+		if predicate(r.Status()) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for the runner status: %w", ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+// Status returns the status of the runner and its services.
+// It never waits for the services to start or stop.
+func (r *runner) Status() *RunnerStatus {
 	status := &RunnerStatus{
-		IsRunning: r.isRunning,
+		IsRunning: r.IsRunning(),
 		Services:  make(map[string]ServiceInfo),
 	}
 
-	for _, svc := range r.svc {
-		status.Services[svc.Name()] = ServiceInfo{
+	for _, svc := range r.services() {
+		info := ServiceInfo{
 			Status:   svc.Status(),
 			Uptime:   svc.Uptime(),
-			Restarts: svc.AutoRestart().RetryCount,
+			Restarts: svc.Retries(),
+			Result:   svc.Result(),
+			Runs:     svc.Runs(),
+			History:  svc.History(),
+			Reload:   svc.LastReload(),
+			Critical: svc.Critical(),
+			Check:    svc.LastHealthCheck(),
+			Details:  svc.Details(),
+
+			ServiceMetadata: svc.Metadata(),
+			ServiceStats:    svc.Stats(),
 		}
+
+		info.Ready = info.Status == ServiceStatusRunning || info.Status == ServiceStatusCompleted
+
+		if next := svc.NextTransition(); !next.IsZero() {
+			info.NextTransition = &next
+		}
+
+		status.Services[svc.Name()] = info
 	}
 
+	status.Health = evaluateHealth(status, r.health.Rules)
+
 	return status
 }
@@ -2,6 +2,8 @@ package glcm
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -56,10 +58,11 @@ func TestStatus(t *testing.T) {
 
 	status := r.Status()
 
-	// drain the uptime for all services
+	// drain the uptime and the stats for all services
 	for k := range status.Services {
 		x := status.Services[k]
 		x.Uptime = 0
+		x.ServiceStats = ServiceStats{}
 		status.Services[k] = x
 	}
 
@@ -78,10 +81,32 @@ func TestStatus(t *testing.T) {
 
 	status = r.Status()
 
-	// drain the uptime for all services
+	// the services go through pending-start, starting and running before they exit.
+	for k := range status.Services {
+		history := status.Services[k].History
+		if assert.Len(t, history, 4, "Expected %s to record its transitions", k) {
+			assert.Equal(t, ServiceStatusRegistered, history[0].From, "Expected %s to start from registered", k)
+			assert.Equal(t, ServiceStatusExited, history[3].To, "Expected %s to end in exited", k)
+		}
+	}
+
+	// the services have started and exited once, without an error.
+	for k := range status.Services {
+		stats := status.Services[k].ServiceStats
+		assert.Equal(t, 1, stats.Starts, "Expected %s to be started once", k)
+		assert.Equal(t, 1, stats.Stops, "Expected %s to be stopped once", k)
+		assert.Equal(t, "exited on its own", stats.LastExitReason, "Expected %s to exit on its own", k)
+		assert.Empty(t, stats.LastError, "Expected no error for %s", k)
+		assert.NotNil(t, stats.LastExit, "Expected the exit time of %s", k)
+		assert.Less(t, stats.Availability, float64(100), "Expected %s to be down", k)
+	}
+
+	// drain the uptime, history and stats for all services
 	for k := range status.Services {
 		x := status.Services[k]
 		x.Uptime = 0
+		x.History = nil
+		x.ServiceStats = ServiceStats{}
 		status.Services[k] = x
 	}
 
@@ -307,3 +332,917 @@ func TestRegisterService(t *testing.T) {
 	_, ok := ri.svc["mockService"]
 	assert.True(t, ok, "Expected service to be registered")
 }
+
+func TestReconcileConditionUnmet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	started := make(chan struct{})
+
+	mockWrapper := NewMockWrapper(ctrl)
+	mockWrapper.EXPECT().Name().Return("mockService").AnyTimes()
+	mockWrapper.EXPECT().Status().Return(ServiceStatusConditionUnmet).AnyTimes()
+	mockWrapper.EXPECT().AutoRestart().Return(&AutoRestart{}).AnyTimes()
+	mockWrapper.EXPECT().InActiveWindow().Return(true).AnyTimes()
+	mockWrapper.EXPECT().Start().Do(func() { close(started) }).Times(1)
+
+	r := NewRunner(context.Background(), RunnerOptions{})
+	ri := r.(*runner)
+
+	ri.svc = map[string]Wrapper{
+		"mockService": mockWrapper,
+	}
+
+	ri.reconcile()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the service with unmet conditions to be re-checked")
+	}
+}
+
+func TestRegisterScheduledService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	lastRun := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	store, err := newStateStore(path)
+	assert.Nil(t, err, "Expected no error for creating the state store")
+	assert.Nil(t, store.set("mockService", ScheduleState{LastRun: lastRun}))
+
+	r := NewRunner(context.Background(), RunnerOptions{StateFile: path})
+	ri := r.(*runner)
+
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().Name().Return("mockService").AnyTimes()
+
+	// Test registering a service with an invalid cron expression
+	err = r.RegisterService(mockService, ServiceOptions{
+		Schedule: SchedulingOptions{Enabled: true, Cron: "invalid"},
+	})
+	assert.ErrorIs(t, err, ErrInvalidSchedule, "Expected error for invalid cron expression")
+
+	err = r.RegisterService(mockService, ServiceOptions{
+		Schedule: SchedulingOptions{Enabled: true, Cron: "@daily", MissedRuns: MissedRunOnce},
+	})
+	assert.Nil(t, err, "Expected no error for registering scheduled service")
+
+	// Test if the persisted schedule state is restored
+	runs := ri.svc["mockService"].Runs()
+	assert.NotNil(t, runs, "Expected run stats for scheduled service")
+	assert.True(t, lastRun.Equal(runs.LastRun), "Expected last run to be restored from the state file")
+}
+
+func TestReconcileOnEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().Name().Return("mockService").AnyTimes()
+	mockService.EXPECT().Start(gomock.Any()).Times(3)
+
+	// the resync is too far to drive the restarts, so they are driven by the exit events.
+	r := NewRunner(context.Background(), RunnerOptions{HideBanner: true, ResyncInterval: time.Hour})
+
+	err := r.RegisterService(mockService, ServiceOptions{
+		AutoStart: AutoRestartOptions{Enabled: true, MaxRetries: 2},
+	})
+	assert.Nil(t, err, "Expected no error for registering service")
+
+	go func() {
+		if err := r.BootUp(); err != nil {
+			t.Errorf("Error while booting up the runner: %v", err)
+		}
+	}()
+
+	deadline := time.After(time.Second)
+
+	for r.Status().Services["mockService"].Status != ServiceStatusExhausted {
+		select {
+		case <-deadline:
+			t.Fatalf("Expected the service to be restarted on its exit events")
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+
+	r.Shutdown()
+}
+
+func TestBackoffWithFakeClock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const maxRetries = 10
+
+	// the service exits right away on every start, the initial start and every retry.
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().Name().Return("mockService").AnyTimes()
+	mockService.EXPECT().Start(gomock.Any()).Times(maxRetries + 1)
+
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	r := NewRunner(context.Background(), RunnerOptions{
+		HideBanner:     true,
+		ResyncInterval: time.Hour,
+		Health:         HealthOptions{CheckInterval: time.Hour},
+		Clock:          clock,
+	})
+
+	err := r.RegisterService(mockService, ServiceOptions{
+		AutoStart: AutoRestartOptions{Enabled: true, MaxRetries: maxRetries, Backoff: true, BackOffExponent: 2},
+	})
+	assert.Nil(t, err, "Expected no error for registering service")
+
+	waitFor := func(status ServiceStatus, restarts int) {
+		deadline := time.After(time.Second)
+
+		for {
+			info := r.Status().Services["mockService"]
+			if info.Status == status && info.Restarts == restarts {
+				return
+			}
+
+			select {
+			case <-deadline:
+				t.Fatalf("Expected the service to be %s after %d restart(s), got %s after %d", status, restarts, info.Status, info.Restarts)
+			case <-time.After(time.Millisecond):
+			}
+		}
+	}
+
+	go func() {
+		if err := r.BootUp(); err != nil {
+			t.Errorf("Error while booting up the runner: %v", err)
+		}
+	}()
+
+	for i := 0; i < maxRetries; i++ {
+		waitFor(ServiceStatusScheduledForRestart, i+1)
+
+		// the resync ticker, the health ticker and the backoff timer.
+		clock.BlockUntil(3)
+
+		backoff := time.Duration(1<<i) * time.Second
+
+		// the service is not restarted before the backoff has passed.
+		clock.Advance(backoff - time.Millisecond)
+		assert.Equal(t, ServiceStatusScheduledForRestart, r.Status().Services["mockService"].Status, "Expected the service to back-off")
+
+		clock.Advance(time.Millisecond)
+	}
+
+	// the service is exhausted after the max retries.
+	waitFor(ServiceStatusExhausted, maxRetries)
+
+	r.Shutdown()
+}
+
+func TestShutdownDuringBackoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the service exits right away, it must not be restarted once the runner is shut down.
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().Name().Return("mockService").AnyTimes()
+	mockService.EXPECT().Start(gomock.Any()).Times(1)
+
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	r := NewRunner(context.Background(), RunnerOptions{
+		HideBanner:     true,
+		ResyncInterval: time.Hour,
+		Health:         HealthOptions{CheckInterval: time.Hour},
+		Signals:        SignalOptions{Disabled: true},
+		Clock:          clock,
+	})
+
+	err := r.RegisterService(mockService, ServiceOptions{
+		AutoStart: AutoRestartOptions{Enabled: true, MaxRetries: 3, Backoff: true, BackOffExponent: 2},
+	})
+	assert.Nil(t, err, "Expected no error for registering service")
+
+	assert.Nil(t, r.Start(context.Background()), "Expected no error starting the runner")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+
+	err = r.WaitForStatus(ctx, "mockService", ServiceStatusScheduledForRestart)
+	assert.Nil(t, err, "Expected the service to be scheduled for restart")
+
+	// the resync ticker, the health ticker and the backoff timer.
+	clock.BlockUntil(3)
+
+	r.Shutdown()
+
+	assert.Equal(t, ServiceStatusStopped, r.Status().Services["mockService"].Status, "Expected the service to be stopped by the shutdown")
+
+	// the backoff is over after the shutdown.
+	clock.Advance(time.Hour)
+
+	assert.Never(t, func() bool {
+		return r.Status().Services["mockService"].Status != ServiceStatusStopped
+	}, time.Millisecond*100, time.Millisecond*10, "Expected the service to stay stopped after the backoff")
+
+	assert.False(t, r.IsRunning(), "Expected runner to not be running after shutdown")
+}
+
+func TestWaitForStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := NewMockService(ctrl)
+	mockService.EXPECT().Name().Return("mockService").AnyTimes()
+	mockService.EXPECT().Start(gomock.Any()).Do(func(t Terminator) { <-t.TermCh() }).Times(1)
+
+	r := NewRunner(context.Background(), RunnerOptions{HideBanner: true, ResyncInterval: time.Hour})
+
+	err := r.RegisterService(mockService, ServiceOptions{})
+	assert.Nil(t, err, "Expected no error for registering service")
+
+	go func() {
+		if err := r.BootUp(); err != nil {
+			t.Errorf("Error while booting up the runner: %v", err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	// Test waiting for the service to be running
+	assert.Nil(t, r.WaitForStatus(ctx, "mockService", ServiceStatusRunning, ServiceStatusExited))
+
+	// Test waiting for an unknown service
+	err = r.WaitForStatus(ctx, "unknown", ServiceStatusRunning)
+	assert.ErrorIs(t, err, ErrDeregisterServiceNotFound, "Expected error for waiting on unknown service")
+
+	// Test waiting for a status which is not reached before the context is done
+	short, cancelShort := context.WithTimeout(ctx, time.Millisecond*50)
+	defer cancelShort()
+
+	err = r.WaitForStatus(short, "mockService", ServiceStatusStopped)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Expected error for waiting past the deadline")
+
+	// Test waiting for a predicate on all the services
+	go func() {
+		_ = r.StopService("mockService")
+	}()
+
+	err = r.WaitForAll(ctx, func(s *RunnerStatus) bool {
+		return s.IsRunning && s.Services["mockService"].Status == ServiceStatusStopped
+	})
+	assert.Nil(t, err, "Expected the service to be stopped")
+
+	r.Shutdown()
+}
+
+func TestStopServiceContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	release := make(chan struct{})
+
+	running := NewMockWrapper(ctrl)
+	running.EXPECT().Status().Return(ServiceStatusRunning).Times(1)
+	running.EXPECT().Stop().Times(1)
+
+	stopped := NewMockWrapper(ctrl)
+	stopped.EXPECT().Status().Return(ServiceStatusStopped).Times(1)
+
+	slow := NewMockWrapper(ctrl)
+	slow.EXPECT().Status().Return(ServiceStatusRunning).Times(1)
+	slow.EXPECT().Stop().Do(func() { <-release }).Times(1)
+
+	r := NewRunner(context.Background(), RunnerOptions{})
+	ri := r.(*runner)
+
+	ri.svc = map[string]Wrapper{
+		"running": running,
+		"stopped": stopped,
+		"slow":    slow,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	res := r.StopServiceContext(ctx, "running", "stopped", "slow", "unknown")
+
+	assert.Len(t, res, 4, "Expected an outcome for every service")
+	assert.Nil(t, res["running"], "Expected the running service to be stopped")
+	assert.ErrorIs(t, res["stopped"], ErrServiceNotRunning, "Expected error for the stopped service")
+	assert.ErrorIs(t, res["slow"], ErrOperationTimeout, "Expected error for the slow service")
+	assert.ErrorIs(t, res["slow"], context.DeadlineExceeded, "Expected the context error for the slow service")
+	assert.ErrorIs(t, res["unknown"], ErrServiceNotFound, "Expected error for the unknown service")
+
+	err := res.Err()
+	assert.ErrorIs(t, err, ErrServiceNotFound, "Expected the joined errors")
+	assert.Contains(t, err.Error(), "unknown: service not found", "Expected the name of the failed service")
+
+	close(release)
+}
+
+func TestDeregisterServiceContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	release := make(chan struct{})
+
+	stopped := NewMockWrapper(ctrl)
+	stopped.EXPECT().Status().Return(ServiceStatusStopped).Times(1)
+
+	slow := NewMockWrapper(ctrl)
+	slow.EXPECT().Status().Return(ServiceStatusRunning).Times(1)
+	slow.EXPECT().Stop().Do(func() { <-release }).Times(1)
+
+	r := NewRunner(context.Background(), RunnerOptions{})
+	ri := r.(*runner)
+
+	ri.svc = map[string]Wrapper{
+		"stopped": stopped,
+		"slow":    slow,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	res := r.DeregisterServiceContext(ctx, "stopped", "slow")
+
+	assert.Nil(t, res["stopped"], "Expected the stopped service to be deregistered")
+	assert.ErrorIs(t, res["slow"], ErrOperationTimeout, "Expected error for the slow service")
+
+	// the service which did not stop in time is not deregistered.
+	_, ok := ri.svc["slow"]
+	assert.True(t, ok, "Expected the slow service to stay registered")
+
+	_, ok = ri.svc["stopped"]
+	assert.False(t, ok, "Expected the stopped service to be deregistered")
+
+	close(release)
+}
+
+func TestStopServiceAsync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	release := make(chan struct{})
+
+	mockWrapper := NewMockWrapper(ctrl)
+	mockWrapper.EXPECT().Status().Return(ServiceStatusRunning).Times(1)
+	mockWrapper.EXPECT().Stop().Do(func() { <-release }).Times(1)
+
+	r := NewRunner(context.Background(), RunnerOptions{})
+	ri := r.(*runner)
+
+	ri.svc = map[string]Wrapper{
+		"mockService": mockWrapper,
+	}
+
+	id := r.StopServiceAsync("mockService", "unknown")
+
+	// the operation is running till the service stops.
+	op, err := r.Operation(id)
+	assert.Nil(t, err, "Expected the submitted operation")
+	assert.Equal(t, OperationRunning, op.State, "Expected the operation to be running")
+
+	close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	op, err = r.WaitOperation(ctx, id)
+	assert.Nil(t, err, "Expected no error waiting for the operation")
+	assert.Equal(t, OperationFailed, op.State, "Expected the operation to fail for the unknown service")
+	assert.Equal(t, Success, op.Results["mockService"].Status, "Expected the service to be stopped")
+	assert.ErrorIs(t, op.Err(), ErrServiceNotFound, "Expected error for the unknown service")
+}
+
+// slowStopService is a service which keeps stopping till it is released.
+type slowStopService struct {
+	name    string
+	release chan struct{}
+}
+
+func (s *slowStopService) Start(t Terminator) {
+	<-t.TermCh()
+	<-s.release
+}
+
+func (s *slowStopService) Name() string {
+	return s.name
+}
+
+// newSlowStopRunner returns a runner with the given number of running services,
+// which keep stopping till the returned channel is closed.
+func newSlowStopRunner(tb testing.TB, n int) (*runner, chan struct{}) {
+	release := make(chan struct{})
+
+	r := NewRunner(context.Background(), RunnerOptions{HideBanner: true}).(*runner)
+
+	for i := 0; i < n; i++ {
+		svc := &slowStopService{name: fmt.Sprintf("service-%d", i), release: release}
+
+		if err := r.RegisterService(svc, ServiceOptions{}); err != nil {
+			tb.Fatalf("registering service: %v", err)
+		}
+	}
+
+	for _, w := range r.services() {
+		go w.Start()
+	}
+
+	waitForAllIn(tb, r, ServiceStatusRunning)
+
+	return r, release
+}
+
+// waitForAllIn waits for all the services of the runner to be in the given status.
+func waitForAllIn(tb testing.TB, r *runner, status ServiceStatus) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	err := r.WaitForAll(ctx, func(s *RunnerStatus) bool {
+		for _, info := range s.Services {
+			if info.Status != status {
+				return false
+			}
+		}
+
+		return true
+	})
+	if err != nil {
+		tb.Fatalf("waiting for the services to be %s: %v", status, err)
+	}
+}
+
+func TestStatusDuringStop(t *testing.T) {
+	r, release := newSlowStopRunner(t, 10)
+	defer close(release)
+
+	go r.StopAllServices()
+	go r.RestartAllServices()
+	go func() { _ = r.StopService("service-0") }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// the status is not blocked behind the services which are stopping.
+	err := r.WaitForAll(ctx, func(s *RunnerStatus) bool {
+		for _, info := range s.Services {
+			if info.Status != ServiceStatusStopping {
+				return false
+			}
+		}
+
+		return true
+	})
+	assert.Nil(t, err, "Expected the status to report the stopping services")
+
+	done := make(chan struct{})
+
+	go func() {
+		r.reconcile()
+		_ = r.RegisterService(&slowStopService{name: "late", release: release}, ServiceOptions{})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the runner not to be blocked by the stopping services")
+	}
+}
+
+func BenchmarkStatus(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("running/%d", n), func(b *testing.B) {
+			r, release := newSlowStopRunner(b, n)
+			defer close(release)
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				r.Status()
+			}
+		})
+
+		// the services keep stopping for the whole benchmark.
+		b.Run(fmt.Sprintf("stopping/%d", n), func(b *testing.B) {
+			r, release := newSlowStopRunner(b, n)
+			defer close(release)
+
+			go r.StopAllServices()
+
+			waitForAllIn(b, r, ServiceStatusStopping)
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				r.Status()
+			}
+		})
+	}
+}
+
+func TestBootUpAfterShutdown(t *testing.T) {
+	// the service stops right away.
+	release := make(chan struct{})
+	close(release)
+
+	svc := &slowStopService{name: "service", release: release}
+
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	r := NewRunner(context.Background(), RunnerOptions{
+		HideBanner: true,
+		Socket:     true,
+		SocketPath: filepath.Join(t.TempDir(), "glcm.sock"),
+		Health:     HealthOptions{CheckInterval: time.Hour},
+		Clock:      clock,
+	})
+
+	err := r.RegisterService(svc, ServiceOptions{})
+	assert.Nil(t, err, "Expected no error for registering service")
+
+	// the failing service is backing-off for its restart, when the runner is shut down.
+	err = r.RegisterService(&failingService{}, ServiceOptions{
+		AutoStart: AutoRestartOptions{Enabled: true, MaxRetries: 3, Backoff: true, BackOffExponent: 2},
+	})
+	assert.Nil(t, err, "Expected no error for registering service")
+
+	for i := 0; i < 2; i++ {
+		booted := make(chan error)
+
+		go func() {
+			booted <- r.BootUp()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+
+		err := r.WaitForStatus(ctx, "service", ServiceStatusRunning)
+		assert.Nil(t, err, "Expected the service to be started on boot up %d", i+1)
+
+		err = r.WaitForStatus(ctx, "failingService", ServiceStatusScheduledForRestart)
+		assert.Nil(t, err, "Expected the failing service to be scheduled for restart on boot up %d", i+1)
+
+		cancel()
+
+		// the resync ticker, the health ticker and the backoff timer.
+		clock.BlockUntil(3)
+
+		r.Shutdown()
+
+		select {
+		case err := <-booted:
+			assert.Nil(t, err, "Expected no error from boot up %d", i+1)
+		case <-time.After(time.Second * 2):
+			t.Fatalf("Expected the boot up %d to return on shutdown", i+1)
+		}
+
+		// the backoff is over after the shutdown, the failing service must not be restarted by it.
+		clock.Advance(time.Hour)
+
+		assert.Never(t, func() bool {
+			return r.Status().Services["failingService"].Status != ServiceStatusStopped
+		}, time.Millisecond*100, time.Millisecond*10, "Expected the failing service to stay stopped after shutdown %d", i+1)
+
+		assert.False(t, r.IsRunning(), "Expected runner to not be running after shutdown")
+		assert.Equal(t, ServiceStatusStopped, r.Status().Services["service"].Status, "Expected the service to be stopped")
+	}
+}
+
+func TestScheduledServiceAfterReboot(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 10, 0, 30, 0, time.UTC))
+
+	r := NewRunner(context.Background(), RunnerOptions{
+		HideBanner: true,
+		Clock:      clock,
+		Signals:    SignalOptions{Disabled: true},
+	})
+
+	err := r.RegisterService(&periodicService{}, ServiceOptions{
+		Schedule: SchedulingOptions{Enabled: true, Cron: "* * * * *", MaxRuns: 1},
+	})
+	assert.Nil(t, err, "Expected no error for registering service")
+
+	var lastRun time.Time
+
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, r.Start(context.Background()), "Expected no error starting the runner on boot up %d", i+1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+
+		// the clock is moved by a minute at a time, till the run is made.
+		completed := make(chan error)
+
+		go func() {
+			completed <- r.WaitForStatus(ctx, "periodicService", ServiceStatusCompleted)
+		}()
+
+	wait:
+		for {
+			select {
+			case err := <-completed:
+				assert.Nil(t, err, "Expected the service to complete its runs on boot up %d", i+1)
+
+				break wait
+			case <-time.After(time.Millisecond * 10):
+				clock.Advance(time.Minute)
+			}
+		}
+
+		cancel()
+
+		runs := r.Status().Services["periodicService"].Runs
+
+		// the runs are counted again on every boot up, so the service runs again rather than completing right away.
+		assert.Equal(t, 1, runs.Count, "Expected one run on boot up %d", i+1)
+		assert.True(t, runs.LastRun.After(lastRun), "Expected a new run on boot up %d", i+1)
+
+		lastRun = runs.LastRun
+
+		r.Shutdown()
+	}
+}
+
+func TestStartAndWait(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+
+	r := NewRunner(context.Background(), RunnerOptions{HideBanner: true})
+
+	err := r.RegisterService(&slowStopService{name: "service", release: release}, ServiceOptions{})
+	assert.Nil(t, err, "Expected no error for registering service")
+
+	select {
+	case <-r.Done():
+	default:
+		t.Fatalf("Expected the done channel to be closed before starting")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	assert.Nil(t, r.Start(ctx), "Expected no error starting the runner")
+	assert.ErrorIs(t, r.Start(ctx), ErrRunnerAlreadyRunning, "Expected error starting a running runner")
+	assert.True(t, r.IsRunning(), "Expected runner to be running once started")
+
+	select {
+	case <-r.Done():
+		t.Fatalf("Expected the done channel to be open while running")
+	default:
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer waitCancel()
+
+	assert.Nil(t, r.WaitForStatus(waitCtx, "service", ServiceStatusRunning), "Expected the service to be started")
+
+	cancel()
+
+	err = r.Wait()
+	assert.ErrorIs(t, err, context.Canceled, "Expected the context to be the reason for the stop")
+	assert.False(t, r.IsRunning(), "Expected runner to not be running after the context is done")
+	assert.Equal(t, ServiceStatusStopped, r.Status().Services["service"].Status, "Expected the service to be stopped")
+
+	// an explicit shutdown is not an error.
+	assert.Nil(t, r.Start(context.Background()), "Expected no error starting the runner again")
+
+	r.Shutdown()
+
+	assert.Nil(t, r.Wait(), "Expected no error after the shutdown")
+}
+
+func TestReloadService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reloaded := NewMockWrapper(ctrl)
+	reloaded.EXPECT().Reload(gomock.Any()).Return(nil).Times(1)
+
+	unsupported := NewMockWrapper(ctrl)
+	unsupported.EXPECT().Reload(gomock.Any()).Return(ErrReloadNotSupported).Times(1)
+
+	r := NewRunner(context.Background(), RunnerOptions{})
+	ri := r.(*runner)
+
+	ri.svc = map[string]Wrapper{
+		"reloaded":    reloaded,
+		"unsupported": unsupported,
+	}
+
+	res := r.ReloadServiceContext(context.Background(), "reloaded", "unsupported", "unknown")
+
+	assert.Nil(t, res["reloaded"], "Expected the service to be reloaded")
+	assert.ErrorIs(t, res["unsupported"], ErrReloadNotSupported, "Expected error for the service without reload")
+	assert.ErrorIs(t, res["unknown"], ErrServiceNotFound, "Expected error for the unknown service")
+}
+
+// failingService exits right away, or panics.
+type failingService struct {
+	panics bool
+}
+
+func (f *failingService) Start(Terminator) {
+	if f.panics {
+		panic("connection lost")
+	}
+}
+
+func (f *failingService) Name() string {
+	return "failingService"
+}
+
+func TestCriticalService(t *testing.T) {
+	tests := []struct {
+		name    string
+		svc     Service
+		opts    ServiceOptions
+		wantErr string
+	}{
+		{
+			name:    "Exits without auto-restart",
+			svc:     &failingService{},
+			opts:    ServiceOptions{Critical: true},
+			wantErr: "failingService is exited",
+		},
+		{
+			name:    "Exhausted",
+			svc:     &failingService{},
+			opts:    ServiceOptions{Critical: true, AutoStart: AutoRestartOptions{Enabled: true, MaxRetries: 2}},
+			wantErr: "failingService is exhausted",
+		},
+		{
+			name:    "Panics",
+			svc:     &failingService{panics: true},
+			opts:    ServiceOptions{Critical: true},
+			wantErr: "failingService is exited",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRunner(context.Background(), RunnerOptions{HideBanner: true, Signals: SignalOptions{Disabled: true}})
+
+			err := r.RegisterService(tt.svc, tt.opts)
+			assert.Nil(t, err, "Expected no error for registering service")
+
+			booted := make(chan error)
+
+			go func() {
+				booted <- r.BootUp()
+			}()
+
+			select {
+			case err := <-booted:
+				assert.ErrorIs(t, err, ErrCriticalServiceFailed, "Expected the critical service to take the runner down")
+				assert.Contains(t, err.Error(), tt.wantErr, "Expected the failed service in the error")
+			case <-time.After(time.Second * 5):
+				r.Shutdown()
+				t.Fatalf("Expected the runner to be shut down by the critical service")
+			}
+
+			assert.False(t, r.IsRunning(), "Expected runner to not be running")
+		})
+	}
+}
+
+func TestNonCriticalServiceExit(t *testing.T) {
+	r := NewRunner(context.Background(), RunnerOptions{HideBanner: true, Signals: SignalOptions{Disabled: true}})
+
+	err := r.RegisterService(&failingService{}, ServiceOptions{})
+	assert.Nil(t, err, "Expected no error for registering service")
+
+	assert.Nil(t, r.Start(context.Background()), "Expected no error starting the runner")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+
+	assert.Nil(t, r.WaitForStatus(ctx, "failingService", ServiceStatusExited), "Expected the service to exit")
+
+	select {
+	case <-r.Done():
+		t.Fatalf("Expected the runner to keep running after a non-critical service exits")
+	case <-time.After(time.Millisecond * 200):
+	}
+
+	r.Shutdown()
+
+	assert.Nil(t, r.Wait(), "Expected no error after the shutdown")
+}
+
+func TestResolve(t *testing.T) {
+	r := NewRunner(context.Background(), RunnerOptions{})
+
+	services := map[string]map[string]string{
+		"ingest-1": {"tier": "ingest", "env": "prod"},
+		"ingest-2": {"tier": "ingest", "env": "canary"},
+		"api":      {"tier": "api", "env": "prod"},
+	}
+
+	for name, labels := range services {
+		err := r.RegisterService(&namedService{name: name}, ServiceOptions{Labels: labels})
+		assert.Nil(t, err, "Expected no error for registering service")
+	}
+
+	err := r.RegisterService(&namedService{name: "invalid"}, ServiceOptions{Labels: map[string]string{"tier": "a,b"}})
+	assert.ErrorIs(t, err, ErrInvalidLabel, "Expected error for registering service with invalid labels")
+
+	tests := []struct {
+		name    string
+		targets []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "Names",
+			targets: []string{"api", "ingest-1"},
+			want:    []string{"api", "ingest-1"},
+		},
+		{
+			name:    "Selector",
+			targets: []string{"tier=ingest,env!=canary"},
+			want:    []string{"ingest-1"},
+		},
+		{
+			name:    "Names and selector",
+			targets: []string{"api", "tier=ingest"},
+			want:    []string{"api", "ingest-1", "ingest-2"},
+		},
+		{
+			name:    "Glob",
+			targets: []string{"ingest-*"},
+			want:    []string{"ingest-1", "ingest-2"},
+		},
+		{
+			name:    "Regex",
+			targets: []string{`re:^ingest-[2-9]$`, "api"},
+			want:    []string{"api", "ingest-2"},
+		},
+		{
+			name:    "Glob without match",
+			targets: []string{"db-*"},
+			want:    []string{},
+			wantErr: ErrNoServiceMatched,
+		},
+		{
+			name:    "Invalid regex",
+			targets: []string{"re:ingest-("},
+			want:    []string{},
+			wantErr: ErrInvalidPattern,
+		},
+		{
+			name:    "Selector without match",
+			targets: []string{"tier=db"},
+			want:    []string{},
+			wantErr: ErrNoServiceMatched,
+		},
+		{
+			name:    "Invalid selector",
+			targets: []string{"tier=ingest,env"},
+			want:    []string{},
+			wantErr: ErrInvalidSelector,
+		},
+		{
+			name:    "Unknown name",
+			targets: []string{"api", "db"},
+			want:    []string{"api"},
+			wantErr: ErrServiceNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := r.Resolve(tt.targets...)
+
+			assert.Equal(t, tt.want, names, "Unexpected matched services")
+			assert.ErrorIs(t, err, tt.wantErr, "Unexpected error")
+		})
+	}
+
+	res := r.StopServiceContext(context.Background(), "tier=ingest")
+	assert.Len(t, res, 2, "Expected the selector to be resolved to the services")
+	assert.ErrorIs(t, res["ingest-1"], ErrServiceNotRunning, "Expected ingest-1 to be stopped")
+	assert.ErrorIs(t, res["ingest-2"], ErrServiceNotRunning, "Expected ingest-2 to be stopped")
+
+	info := r.Status().Services["api"]
+	assert.Equal(t, map[string]string{"tier": "api", "env": "prod"}, info.Labels, "Expected the labels in the status")
+
+	// the patterns and the selectors are not resolved for the deregistration.
+	assert.ErrorIs(t, r.DeregisterService("ingest-*"), ErrDeregisterServiceNotFound, "Expected error deregistering by pattern")
+	assert.ErrorIs(t, r.DeregisterServiceContext(context.Background(), "tier=ingest").Err(), ErrServiceNotFound, "Expected error deregistering by selector")
+	assert.Len(t, r.Status().Services, 3, "Expected no service to be deregistered by pattern or selector")
+}
+
+// namedService is a long running service with the given name.
+type namedService struct {
+	name string
+}
+
+func (n *namedService) Start(t Terminator) {
+	<-t.TermCh()
+}
+
+func (n *namedService) Name() string {
+	return n.name
+}
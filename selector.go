@@ -0,0 +1,99 @@
+package glcm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Selector selects the services by their labels, e.g. tier=ingest,env!=canary.
+// A service is selected if it meets all the requirements of the selector.
+type Selector []requirement
+
+// requirement is a single key/value requirement of a selector.
+type requirement struct {
+	key   string
+	value string
+
+	// negate represents if the label must not have the value. A missing label meets a negated requirement.
+	negate bool
+}
+
+// isSelector returns true if the target of a control operation is a label selector, rather than a service name.
+func isSelector(target string) bool {
+	return strings.Contains(target, "=")
+}
+
+// ParseSelector parses the comma separated requirements of the selector.
+// The supported operators are = (or ==) and !=.
+func ParseSelector(s string) (Selector, error) {
+	var sel Selector
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+
+		var (
+			req requirement
+			ok  bool
+		)
+
+		switch {
+		case strings.Contains(part, "!="):
+			req.key, req.value, ok = strings.Cut(part, "!=")
+			req.negate = true
+		case strings.Contains(part, "=="):
+			req.key, req.value, ok = strings.Cut(part, "==")
+		default:
+			req.key, req.value, ok = strings.Cut(part, "=")
+		}
+
+		req.key, req.value = strings.TrimSpace(req.key), strings.TrimSpace(req.value)
+
+		if !ok || req.key == "" || strings.ContainsAny(req.key, "=!") || strings.Contains(req.value, "=") {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSelector, part)
+		}
+
+		sel = append(sel, req)
+	}
+
+	return sel, nil
+}
+
+// Matches returns true if the labels meet all the requirements of the selector.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, req := range s {
+		v, ok := labels[req.key]
+
+		if req.negate == (ok && v == req.value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns the selector in its canonical form.
+func (s Selector) String() string {
+	parts := make([]string, 0, len(s))
+
+	for _, req := range s {
+		op := "="
+		if req.negate {
+			op = "!="
+		}
+
+		parts = append(parts, req.key+op+req.value)
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// validateLabels makes sure that the labels of a service can be used in the selectors.
+func validateLabels(labels map[string]string) error {
+	for k, v := range labels {
+		if k == "" || strings.ContainsAny(k, "=!, ") || strings.ContainsAny(v, "=, ") {
+			return fmt.Errorf("%w: %q=%q", ErrInvalidLabel, k, v)
+		}
+	}
+
+	return nil
+}
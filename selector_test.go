@@ -0,0 +1,119 @@
+package glcm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "Equality",
+			selector: "tier=ingest",
+			want:     "tier=ingest",
+		},
+		{
+			name:     "Double equality and inequality",
+			selector: "tier==ingest, env!=canary",
+			want:     "tier=ingest,env!=canary",
+		},
+		{
+			name:     "Empty value",
+			selector: "env=",
+			want:     "env=",
+		},
+		{
+			name:     "Missing operator",
+			selector: "tier=ingest,env",
+			wantErr:  true,
+		},
+		{
+			name:     "Missing key",
+			selector: "=ingest",
+			wantErr:  true,
+		},
+		{
+			name:     "Repeated operator",
+			selector: "tier=ingest=api",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := ParseSelector(tt.selector)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSelector, "Expected error for invalid selector")
+
+				return
+			}
+
+			assert.Nil(t, err, "Expected no error for valid selector")
+			assert.Equal(t, tt.want, sel.String(), "Unexpected selector")
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{"tier": "ingest", "env": "prod"}
+
+	tests := []struct {
+		name     string
+		selector string
+		labels   map[string]string
+		want     bool
+	}{
+		{
+			name:     "All requirements met",
+			selector: "tier=ingest,env!=canary",
+			labels:   labels,
+			want:     true,
+		},
+		{
+			name:     "Value does not match",
+			selector: "tier=api",
+			labels:   labels,
+			want:     false,
+		},
+		{
+			name:     "Negated value matches",
+			selector: "tier=ingest,env!=prod",
+			labels:   labels,
+			want:     false,
+		},
+		{
+			name:     "Missing label meets negated requirement",
+			selector: "zone!=eu",
+			labels:   labels,
+			want:     true,
+		},
+		{
+			name:     "No labels",
+			selector: "tier=ingest",
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := ParseSelector(tt.selector)
+			assert.Nil(t, err, "Expected no error for valid selector")
+
+			assert.Equal(t, tt.want, sel.Matches(tt.labels), "Unexpected match")
+		})
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	assert.Nil(t, validateLabels(map[string]string{"tier": "ingest", "env": ""}), "Expected no error for valid labels")
+	assert.ErrorIs(t, validateLabels(map[string]string{"": "ingest"}), ErrInvalidLabel, "Expected error for empty key")
+	assert.ErrorIs(t, validateLabels(map[string]string{"tier": "a,b"}), ErrInvalidLabel, "Expected error for value with comma")
+	assert.ErrorIs(t, validateLabels(map[string]string{"ti!er": "ingest"}), ErrInvalidLabel, "Expected error for key with operator")
+}
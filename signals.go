@@ -0,0 +1,231 @@
+package glcm
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+
+	"github.com/achu-1612/glcm/log"
+)
+
+// defaultShutdownSignals are the signals which shut down the runner, unless configured otherwise.
+var defaultShutdownSignals = []os.Signal{syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGHUP}
+
+// defaultReloadSignal is the signal which reloads the services, unless configured otherwise.
+var defaultReloadSignal os.Signal = syscall.SIGHUP
+
+// hasSignal returns true if the signal is in the given list.
+func hasSignal(sigs []os.Signal, sig os.Signal) bool {
+	for _, s := range sigs {
+		if s == sig {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notifySignals subscribes to the handled OS signals and ignores the ignored ones.
+// It returns the channel of the signals (nil if the handling is disabled) along with a function to undo it.
+// The signals subscribed by the services are added to the channel, while the runner is running.
+func (r *runner) notifySignals() (<-chan os.Signal, func()) {
+	if r.signals.Disabled {
+		return nil, func() {}
+	}
+
+	var handled []os.Signal
+
+	handled = append(handled, r.signals.Shutdown...)
+	handled = append(handled, r.signals.Reload...)
+	handled = append(handled, r.signals.Status...)
+
+	sigs := make(chan os.Signal, 1)
+
+	// notify with no signal relays all the signals, which is not what an empty list means here.
+	if len(handled) > 0 {
+		signal.Notify(sigs, handled...)
+	}
+
+	if len(r.signals.Ignore) > 0 {
+		signal.Ignore(r.signals.Ignore...)
+	}
+
+	r.mu.Lock()
+	r.sigs = sigs
+	r.mu.Unlock()
+
+	return sigs, func() {
+		r.mu.Lock()
+		r.sigs = nil
+		r.mu.Unlock()
+
+		signal.Stop(sigs)
+
+		if len(r.signals.Ignore) > 0 {
+			signal.Reset(r.signals.Ignore...)
+		}
+	}
+}
+
+// subscribeSignals adds the signals subscribed by a service to the OS signals of the runner.
+func (r *runner) subscribeSignals(sig ...os.Signal) {
+	r.mu.Lock()
+	sigs := r.sigs
+	r.mu.Unlock()
+
+	if sigs != nil && len(sig) > 0 {
+		signal.Notify(sigs, sig...)
+	}
+}
+
+// forwardSignal delivers the signal to the services which have subscribed to it.
+// It returns the number of the services the signal is delivered to.
+func (r *runner) forwardSignal(sig os.Signal) int {
+	n := 0
+
+	for _, w := range r.services() {
+		if err := w.Signal(sig); err == nil {
+			n++
+		}
+	}
+
+	return n
+}
+
+// SignalService delivers a synthetic signal to the given service.
+func (r *runner) SignalService(name string, sig os.Signal) error {
+	ws := r.services(name)
+	if len(ws) == 0 {
+		return fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+
+	return ws[0].Signal(sig)
+}
+
+// handleSignal takes the configured action for the signal, after forwarding it to the subscribed services.
+// It returns true if the runner has been shut down.
+func (r *runner) handleSignal(sig os.Signal) bool {
+	forwarded := r.forwardSignal(sig)
+
+	switch {
+	case hasSignal(r.signals.Shutdown, sig):
+		log.Infof("Received shutdown signal %s. Shutting down the runner ...", sig)
+
+		r.stop(fmt.Errorf("%w: %s", ErrShutdownSignal, sig))
+
+		return true
+
+	// the default reload signal keeps shutting down the runner, if there is no service to reload.
+	case hasSignal(r.signals.Reload, sig) && r.signals.defaultReload && !r.reloadable():
+		log.Infof("Received signal %s with no reloadable service. Shutting down the runner ...", sig)
+
+		r.stop(fmt.Errorf("%w: %s", ErrShutdownSignal, sig))
+
+		return true
+
+	case hasSignal(r.signals.Reload, sig):
+		log.Infof("Received reload signal %s. Reloading the services ...", sig)
+
+		// the reload might take a while, the runner keeps reconciling meanwhile.
+		go r.reloadAll()
+
+	case hasSignal(r.signals.Status, sig):
+		r.logStatus()
+
+	case forwarded > 0:
+		log.Infof("Forwarded signal %s to %d service(s)", sig, forwarded)
+
+	default:
+		log.Infof("Ignoring signal %s", sig)
+	}
+
+	return false
+}
+
+// reloadable returns true if any of the registered services implements the Reloader interface.
+func (r *runner) reloadable() bool {
+	for _, w := range r.services() {
+		if w.Reloadable() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// signalSubscription holds the process signals to which a run of a service has subscribed.
+type signalSubscription struct {
+	// mu is a mutex to protect the subscribed signals.
+	mu *sync.Mutex
+
+	// sigs is the set of the subscribed signals.
+	sigs map[os.Signal]struct{}
+
+	// ch is the channel on which the signals are delivered.
+	ch chan os.Signal
+}
+
+// newSignalSubscription returns a new instance of the signal subscription, with no signal.
+func newSignalSubscription() *signalSubscription {
+	return &signalSubscription{
+		mu:   &sync.Mutex{},
+		sigs: make(map[os.Signal]struct{}),
+		ch:   make(chan os.Signal, 1),
+	}
+}
+
+// subscribe adds the signals to the subscription, and returns the channel of the subscription.
+func (s *signalSubscription) subscribe(sig ...os.Signal) <-chan os.Signal {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sg := range sig {
+		s.sigs[sg] = struct{}{}
+	}
+
+	return s.ch
+}
+
+// deliver sends the signal on the channel, if it is subscribed.
+// Like the os/signal package, it never blocks and the signal is dropped if the channel is full.
+func (s *signalSubscription) deliver(sig os.Signal) error {
+	s.mu.Lock()
+	_, ok := s.sigs[sig]
+	s.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrSignalNotSubscribed, sig)
+	}
+
+	select {
+	case s.ch <- sig:
+	default:
+		log.Warnf("Dropping signal %s, the previous one is not received yet", sig)
+	}
+
+	return nil
+}
+
+// logStatus dumps the status of the services to the logs.
+func (r *runner) logStatus() {
+	status := r.Status()
+
+	names := make([]string, 0, len(status.Services))
+
+	for name := range status.Services {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	log.Infof("Runner status: running=%t, services=%d", status.IsRunning, len(names))
+
+	for _, name := range names {
+		info := status.Services[name]
+
+		log.Infof("Service %s: %s (uptime %s, restarts %d)", name, info.Status, info.Uptime, info.Restarts)
+	}
+}
@@ -0,0 +1,293 @@
+package glcm
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignalOptionsSanitize(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       SignalOptions
+		want       []os.Signal
+		wantReload []os.Signal
+	}{
+		{
+			name:       "Default signals",
+			opts:       SignalOptions{},
+			want:       []os.Signal{syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT},
+			wantReload: []os.Signal{syscall.SIGHUP},
+		},
+		{
+			name:       "Reload on hangup",
+			opts:       SignalOptions{Reload: []os.Signal{syscall.SIGHUP}},
+			want:       []os.Signal{syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT},
+			wantReload: []os.Signal{syscall.SIGHUP},
+		},
+		{
+			name:       "No reload signals",
+			opts:       SignalOptions{Reload: []os.Signal{}},
+			want:       []os.Signal{syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGHUP},
+			wantReload: []os.Signal{},
+		},
+		{
+			name:       "Ignore interrupt, quit and hangup",
+			opts:       SignalOptions{Ignore: []os.Signal{syscall.SIGINT, syscall.SIGQUIT, syscall.SIGHUP}},
+			want:       []os.Signal{syscall.SIGTERM},
+			wantReload: nil,
+		},
+		{
+			name:       "Given shutdown signals",
+			opts:       SignalOptions{Shutdown: []os.Signal{syscall.SIGTERM}},
+			want:       []os.Signal{syscall.SIGTERM},
+			wantReload: []os.Signal{syscall.SIGHUP},
+		},
+		{
+			name:       "Shutdown on hangup",
+			opts:       SignalOptions{Shutdown: []os.Signal{syscall.SIGTERM, syscall.SIGHUP}},
+			want:       []os.Signal{syscall.SIGTERM, syscall.SIGHUP},
+			wantReload: nil,
+		},
+		{
+			name:       "No shutdown signals",
+			opts:       SignalOptions{Shutdown: []os.Signal{}},
+			want:       []os.Signal{},
+			wantReload: []os.Signal{syscall.SIGHUP},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Sanitize()
+
+			assert.Equal(t, tt.want, tt.opts.Shutdown, "Unexpected shutdown signals")
+			assert.Equal(t, tt.wantReload, tt.opts.Reload, "Unexpected reload signals")
+		})
+	}
+}
+
+// countingService counts its starts and runs till it is stopped.
+type countingService struct {
+	starts atomic.Int32
+}
+
+func (c *countingService) Start(t Terminator) {
+	c.starts.Add(1)
+	<-t.TermCh()
+}
+
+func (c *countingService) Name() string {
+	return "countingService"
+}
+
+func TestSignalTrigger(t *testing.T) {
+	trigger := make(chan os.Signal)
+
+	r := NewRunner(context.Background(), RunnerOptions{
+		HideBanner: true,
+		Signals: SignalOptions{
+			Disabled: true,
+			Reload:   []os.Signal{syscall.SIGHUP},
+			Status:   []os.Signal{syscall.SIGQUIT},
+			Trigger:  trigger,
+		},
+	})
+
+	svc := &countingService{}
+
+	// the service can not be reloaded, it is restarted instead.
+	err := r.RegisterService(svc, ServiceOptions{ReloadFallback: true})
+	assert.Nil(t, err, "Expected no error for registering service")
+
+	assert.Nil(t, r.Start(context.Background()), "Expected no error starting the runner")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+
+	assert.Nil(t, r.WaitForStatus(ctx, "countingService", ServiceStatusRunning), "Expected the service to be started")
+
+	// the status signal only dumps the status.
+	trigger <- syscall.SIGQUIT
+
+	assert.True(t, r.IsRunning(), "Expected runner to be running after the status signal")
+
+	// the reload signal reloads the running service.
+	trigger <- syscall.SIGHUP
+
+	deadline := time.After(time.Second * 2)
+
+	for svc.starts.Load() < 2 {
+		select {
+		case <-deadline:
+			t.Fatalf("Expected the service to be restarted on the reload signal")
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+
+	trigger <- syscall.SIGTERM
+
+	err = r.Wait()
+	assert.ErrorIs(t, err, ErrShutdownSignal, "Expected the signal to be the reason for the stop")
+	assert.Contains(t, err.Error(), syscall.SIGTERM.String(), "Expected the signal in the reason")
+}
+
+// reloadingService is a service which can be reloaded.
+type reloadingService struct {
+	countingService
+}
+
+func (r *reloadingService) Reload(context.Context) error {
+	return nil
+}
+
+func TestDefaultReloadSignal(t *testing.T) {
+	tests := []struct {
+		name       string
+		svc        Service
+		wantReload bool
+	}{
+		{
+			name:       "Reloadable service",
+			svc:        &reloadingService{},
+			wantReload: true,
+		},
+		{
+			name: "No reloadable service",
+			svc:  &countingService{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trigger := make(chan os.Signal)
+
+			// the default signals are handled for the trigger.
+			r := NewRunner(context.Background(), RunnerOptions{
+				HideBanner: true,
+				Signals:    SignalOptions{Disabled: true, Trigger: trigger},
+			})
+
+			err := r.RegisterService(tt.svc, ServiceOptions{})
+			assert.Nil(t, err, "Expected no error for registering service")
+
+			assert.Nil(t, r.Start(context.Background()), "Expected no error starting the runner")
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+			defer cancel()
+
+			assert.Nil(t, r.WaitForStatus(ctx, "countingService", ServiceStatusRunning), "Expected the service to be started")
+
+			trigger <- syscall.SIGHUP
+
+			if !tt.wantReload {
+				err = r.Wait()
+				assert.ErrorIs(t, err, ErrShutdownSignal, "Expected the hangup to shut down the runner")
+				assert.Contains(t, err.Error(), syscall.SIGHUP.String(), "Expected the signal in the reason")
+
+				return
+			}
+
+			err = r.WaitForAll(ctx, func(s *RunnerStatus) bool {
+				return s.Services["countingService"].Reload != nil
+			})
+			assert.Nil(t, err, "Expected the service to be reloaded on the hangup")
+			assert.True(t, r.IsRunning(), "Expected runner to be running after the hangup")
+
+			r.Shutdown()
+		})
+	}
+}
+
+func TestSignalSubscription(t *testing.T) {
+	s := newSignalSubscription()
+
+	assert.ErrorIs(t, s.deliver(syscall.SIGUSR1), ErrSignalNotSubscribed, "Expected error for a signal which is not subscribed")
+
+	ch := s.subscribe(syscall.SIGUSR1, syscall.SIGUSR2)
+
+	assert.Nil(t, s.deliver(syscall.SIGUSR1), "Expected the subscribed signal to be delivered")
+
+	// the channel is full, the signal is dropped without blocking.
+	assert.Nil(t, s.deliver(syscall.SIGUSR2), "Expected no error for a dropped signal")
+
+	assert.Equal(t, syscall.SIGUSR1, <-ch, "Expected the first signal to be received")
+
+	select {
+	case sig := <-ch:
+		t.Fatalf("Expected the second signal to be dropped, got %s", sig)
+	default:
+	}
+}
+
+// subscriberService forwards the signals it has subscribed to.
+type subscriberService struct {
+	received chan os.Signal
+}
+
+func (s *subscriberService) Start(t Terminator) {
+	sigs := t.Signals(syscall.SIGUSR1)
+
+	for {
+		select {
+		case sig := <-sigs:
+			s.received <- sig
+		case <-t.TermCh():
+			return
+		}
+	}
+}
+
+func (s *subscriberService) Name() string {
+	return "subscriberService"
+}
+
+func TestSignalForwarding(t *testing.T) {
+	trigger := make(chan os.Signal)
+
+	r := NewRunner(context.Background(), RunnerOptions{
+		HideBanner: true,
+		Signals:    SignalOptions{Disabled: true, Trigger: trigger},
+	})
+
+	svc := &subscriberService{received: make(chan os.Signal, 1)}
+
+	err := r.RegisterService(svc, ServiceOptions{})
+	assert.Nil(t, err, "Expected no error for registering service")
+
+	assert.ErrorIs(t, r.SignalService("subscriberService", syscall.SIGUSR1), ErrServiceNotRunning, "Expected error before the service is running")
+
+	assert.Nil(t, r.Start(context.Background()), "Expected no error starting the runner")
+	defer r.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+
+	assert.Nil(t, r.WaitForStatus(ctx, "subscriberService", ServiceStatusRunning), "Expected the service to be started")
+
+	// the service subscribes right after it is running.
+	deadline := time.After(time.Second)
+
+	for r.SignalService("subscriberService", syscall.SIGUSR1) != nil {
+		select {
+		case <-deadline:
+			t.Fatalf("Expected the service to subscribe to the signal")
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+
+	assert.Equal(t, syscall.SIGUSR1, <-svc.received, "Expected the synthetic signal to be delivered")
+
+	trigger <- syscall.SIGUSR1
+
+	assert.Equal(t, syscall.SIGUSR1, <-svc.received, "Expected the process signal to be forwarded")
+	assert.True(t, r.IsRunning(), "Expected runner to be running after the forwarded signal")
+
+	assert.ErrorIs(t, r.SignalService("subscriberService", syscall.SIGUSR2), ErrSignalNotSubscribed, "Expected error for a signal which is not subscribed")
+	assert.ErrorIs(t, r.SignalService("unknown", syscall.SIGUSR1), ErrServiceNotFound, "Expected error for the unknown service")
+}
@@ -2,10 +2,12 @@ package glcm
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net"
 	"os"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -13,6 +15,8 @@ import (
 	"github.com/achu-1612/glcm/log"
 )
 
+// socketOperationTimeout is the maximum time a socket command waits for the services to stop.
+const socketOperationTimeout = time.Second * 30
 
 // socketAction is a type for socket actions.
 type socketAction string
@@ -24,6 +28,23 @@ const (
 	SocketActionRestartAll      socketAction = "restartAll"
 	SocketActionRestartService  socketAction = "restart"
 	SocketActionStatus          socketAction = "status"
+	SocketActionReloadService   socketAction = "reload"
+	SocketActionSignalService   socketAction = "signal"
+	SocketActionHealth          socketAction = "health"
+	SocketActionMatch           socketAction = "match"
+
+	// asynchronous actions, which return the id of the operation.
+	SocketActionStopServiceAsync    socketAction = "stopAsync"
+	SocketActionRestartServiceAsync socketAction = "restartAsync"
+
+	// SocketActionOperation follows an asynchronous operation with the status or wait sub-command.
+	SocketActionOperation socketAction = "op"
+)
+
+// list of supported sub-commands for the operation action
+const (
+	SocketOperationStatus = "status"
+	SocketOperationWait   = "wait"
 )
 
 // socketCommandStatus is a type for socket action status.
@@ -41,6 +62,12 @@ type SocketResponse struct {
 	Status socketCommandStatus `json:"status"`
 }
 
+// ServiceOutcome represents the outcome of a socket command for a single service.
+type ServiceOutcome struct {
+	Result string              `json:"result"`
+	Status socketCommandStatus `json:"status"`
+}
+
 // socket implements basic socket operations
 type socket struct {
 	r          Runner
@@ -60,13 +87,20 @@ func newSocket(
 		r:          r,
 		socketPath: socketPath,
 		allowedUID: allowedUIDs,
-		shutdownCh: make(chan struct{}),
-		doneCh:     make(chan struct{}),
 	}
 
+	s.reset()
+
 	return s, nil
 }
 
+// reset reallocates the channels of the socket, so that it can be started again after a shutdown.
+// It must not be called while the socket is running.
+func (s *socket) reset() {
+	s.shutdownCh = make(chan struct{})
+	s.doneCh = make(chan struct{})
+}
+
 // stopService stops the service with the given name(s).
 func (s *socket) stopService(name ...string) *SocketResponse {
 	if len(name) == 0 {
@@ -76,17 +110,25 @@ func (s *socket) stopService(name ...string) *SocketResponse {
 		}
 	}
 
-	if err := s.r.StopService(name...); err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), socketOperationTimeout)
+	defer cancel()
+
+	return resultsResponse(s.r.StopServiceContext(ctx, name...), "stopped")
+}
+
+// reloadService reloads the service with the given name(s).
+func (s *socket) reloadService(name ...string) *SocketResponse {
+	if len(name) == 0 {
 		return &SocketResponse{
-			Result: fmt.Sprintf("failed to stop service(s)- %v: %v", name, err),
+			Result: "no service name provided",
 			Status: Failure,
 		}
 	}
 
-	return &SocketResponse{
-		Result: fmt.Sprintf("service(s) stopped successfully: %v", name),
-		Status: Success,
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), socketOperationTimeout)
+	defer cancel()
+
+	return resultsResponse(s.r.ReloadServiceContext(ctx, name...), "reloaded")
 }
 
 // stopAllServices stops all the services.
@@ -108,19 +150,177 @@ func (s *socket) restartService(name ...string) *SocketResponse {
 		}
 	}
 
-	if err := s.r.RestartService(name...); err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), socketOperationTimeout)
+	defer cancel()
+
+	return resultsResponse(s.r.RestartServiceContext(ctx, name...), "restarted")
+}
+
+// resultsResponse returns the response with the outcome for each of the services by name.
+// The response is a failure, if the operation has failed for any of the services.
+func resultsResponse(res ServiceResults, done string) *SocketResponse {
+	out, ok := serviceOutcomes(res, done)
+
+	status := Success
+	if !ok {
+		status = Failure
+	}
+
+	return &SocketResponse{
+		Result: out,
+		Status: status,
+	}
+}
+
+// serviceOutcomes converts the results of an operation to the outcome for each of the services.
+// The done message is reported for the successful services.
+// It returns false if the operation has failed for any of the services.
+func serviceOutcomes(res ServiceResults, done string) (map[string]ServiceOutcome, bool) {
+	out := make(map[string]ServiceOutcome, len(res))
+	ok := true
+
+	for name, err := range res {
+		if err != nil {
+			out[name] = ServiceOutcome{Result: err.Error(), Status: Failure}
+			ok = false
+
+			continue
+		}
+
+		out[name] = ServiceOutcome{Result: done, Status: Success}
+	}
+
+	return out, ok
+}
+
+// submitService submits the asynchronous action on the service(s) with the given name(s).
+func (s *socket) submitService(action socketAction, name ...string) *SocketResponse {
+	if len(name) == 0 {
 		return &SocketResponse{
-			Result: fmt.Sprintf("failed to restart service(s)- %v: %v", name, err),
+			Result: "no service name provided",
+			Status: Failure,
+		}
+	}
+
+	var id string
+
+	if action == SocketActionStopServiceAsync {
+		id = s.r.StopServiceAsync(name...)
+	} else {
+		id = s.r.RestartServiceAsync(name...)
+	}
+
+	return &SocketResponse{
+		Result: id,
+		Status: Success,
+	}
+}
+
+// signalNames are the names of the signals which can be delivered to a service, without the SIG prefix.
+var signalNames = map[string]syscall.Signal{
+	"HUP":   syscall.SIGHUP,
+	"INT":   syscall.SIGINT,
+	"QUIT":  syscall.SIGQUIT,
+	"TERM":  syscall.SIGTERM,
+	"USR1":  syscall.SIGUSR1,
+	"USR2":  syscall.SIGUSR2,
+	"ALRM":  syscall.SIGALRM,
+	"WINCH": syscall.SIGWINCH,
+}
+
+// parseSignal parses the signal from its name (with or without the SIG prefix) or its number.
+func parseSignal(name string) (os.Signal, error) {
+	if n, err := strconv.Atoi(name); err == nil && n > 0 {
+		return syscall.Signal(n), nil
+	}
+
+	if sig, ok := signalNames[strings.TrimPrefix(strings.ToUpper(name), "SIG")]; ok {
+		return sig, nil
+	}
+
+	return nil, fmt.Errorf("unknown signal: %s", name)
+}
+
+// signalService delivers a synthetic signal to the service.
+func (s *socket) signalService(args ...string) *SocketResponse {
+	if len(args) != 2 {
+		return &SocketResponse{
+			Result: fmt.Sprintf("usage: %s <service> <signal>", SocketActionSignalService),
+			Status: Failure,
+		}
+	}
+
+	sig, err := parseSignal(args[1])
+	if err != nil {
+		return &SocketResponse{
+			Result: err.Error(),
+			Status: Failure,
+		}
+	}
+
+	if err := s.r.SignalService(args[0], sig); err != nil {
+		return &SocketResponse{
+			Result: err.Error(),
 			Status: Failure,
 		}
 	}
 
 	return &SocketResponse{
-		Result: fmt.Sprintf("service(s) restarted successfully: %v", name),
+		Result: fmt.Sprintf("signal %s delivered to service %s", sig, args[0]),
 		Status: Success,
 	}
 }
 
+// operation returns the status of the asynchronous operation, or waits for it to finish.
+// The response of the wait is a failure, unless the operation has succeeded.
+func (s *socket) operation(args ...string) *SocketResponse {
+	if len(args) != 2 {
+		return &SocketResponse{
+			Result: fmt.Sprintf("usage: %s %s|%s <id>", SocketActionOperation, SocketOperationStatus, SocketOperationWait),
+			Status: Failure,
+		}
+	}
+
+	var (
+		op  *Operation
+		err error
+	)
+
+	switch args[0] {
+	case SocketOperationStatus:
+		op, err = s.r.Operation(args[1])
+
+	case SocketOperationWait:
+		ctx, cancel := context.WithTimeout(context.Background(), socketOperationTimeout)
+		defer cancel()
+
+		op, err = s.r.WaitOperation(ctx, args[1])
+
+	default:
+		return &SocketResponse{
+			Result: fmt.Sprintf("unknown operation command: %s", args[0]),
+			Status: Failure,
+		}
+	}
+
+	if err != nil {
+		return &SocketResponse{
+			Result: err.Error(),
+			Status: Failure,
+		}
+	}
+
+	status := Success
+	if args[0] == SocketOperationWait && op.State != OperationSucceeded {
+		status = Failure
+	}
+
+	return &SocketResponse{
+		Result: op,
+		Status: status,
+	}
+}
+
 // restartAllServices restarts all the services.
 func (s *socket) restartAllServices() *SocketResponse {
 	s.r.RestartAllServices()
@@ -132,13 +332,80 @@ func (s *socket) restartAllServices() *SocketResponse {
 }
 
 // status returns the status of the runner along with the status of each registered service.
-func (s *socket) status() *SocketResponse {
+// The services are limited to the ones matched by the targets (names or label selectors), if any is given.
+func (s *socket) status(targets ...string) *SocketResponse {
+	status := s.r.Status()
+
+	if len(targets) == 0 {
+		return &SocketResponse{
+			Result: status,
+			Status: Success,
+		}
+	}
+
+	names, err := s.r.Resolve(targets...)
+	if err != nil {
+		return &SocketResponse{
+			Result: err.Error(),
+			Status: Failure,
+		}
+	}
+
+	services := make(map[string]ServiceInfo, len(names))
+
+	for _, name := range names {
+		if info, ok := status.Services[name]; ok {
+			services[name] = info
+		}
+	}
+
+	status.Services = services
+
 	return &SocketResponse{
-		Result: s.r.Status(),
+		Result: status,
 		Status: Success,
 	}
 }
 
+// match returns the names of the services matched by the targets (names, patterns or label selectors),
+// without acting on them.
+func (s *socket) match(targets ...string) *SocketResponse {
+	if len(targets) == 0 {
+		return &SocketResponse{
+			Result: "no service name provided",
+			Status: Failure,
+		}
+	}
+
+	names, err := s.r.Resolve(targets...)
+	if err != nil {
+		return &SocketResponse{
+			Result: err.Error(),
+			Status: Failure,
+		}
+	}
+
+	return &SocketResponse{
+		Result: names,
+		Status: Success,
+	}
+}
+
+// health returns the overall health of the runner. It fails when the runner is unhealthy.
+func (s *socket) health() *SocketResponse {
+	h := s.r.Health()
+
+	status := Success
+	if h.State == HealthUnhealthy {
+		status = Failure
+	}
+
+	return &SocketResponse{
+		Result: h,
+		Status: status,
+	}
+}
+
 // func (s *socket) shutdownRunner() *SocketResponse {
 // 	s.r.Shutdown()
 // 	return &SocketResponse{
@@ -158,6 +425,12 @@ func (s *socket) shutdown() {
 // Note: This will be a blocking call. Once the shutdown on the socket is called,
 // the server will be stopped and the socket file will be removed.
 func (s *socket) start() error {
+	// the channels are reallocated on every boot up, the ones of this run are kept.
+	shutdownCh, doneCh := s.shutdownCh, s.doneCh
+
+	// notify the shutdown call that the socket is closed.
+	defer close(doneCh)
+
 	if s.socketPath == "" {
 		s.socketPath = defaultSocketPath
 
@@ -176,38 +449,39 @@ func (s *socket) start() error {
 		return fmt.Errorf("creating socket listener: %w", err)
 	}
 
-	// on shutdown, close the socket and remove the socket file
+	if err := os.Chmod(s.socketPath, 0600); err != nil {
+		sock.Close()
+
+		return fmt.Errorf("setting file permission for the socket file: %v", err)
+	}
+
+	// on shutdown, close the socket, which unblocks the accept below.
 	go func() {
-		<-s.shutdownCh
+		<-shutdownCh
 
 		log.Info("Closing the socket listener")
 
 		if err := sock.Close(); err != nil {
 			log.Errorf("Close socket listener: %v", err)
 		}
-
-		log.Info("Removing socket file")
-
-		if err := os.Remove(s.socketPath); err != nil {
-			log.Errorf("Remove socket file: %v", err)
-		}
-
-		log.Info("Socket closed and file removed")
-
-		close(s.doneCh) // notifiy the shutdown call that the socket is closed.
 	}()
 
-	if err := os.Chmod(s.socketPath, 0600); err != nil {
-		return fmt.Errorf("setting file permission for the socket file: %v", err)
-	}
-
 	log.Infof("Listening on %s. Permitted Access for user: %v", s.socketPath, s.allowedUID)
 
 	for {
 		conn, err := sock.Accept()
 		if err != nil {
 			select {
-			case <-s.shutdownCh:
+			case <-shutdownCh:
+				log.Info("Removing socket file")
+
+				// the listener might have removed the file on close.
+				if err := os.Remove(s.socketPath); err != nil && !os.IsNotExist(err) {
+					log.Errorf("Remove socket file: %v", err)
+				}
+
+				log.Info("Socket closed and file removed")
+
 				return nil
 			default:
 				log.Errorf("Accepting connection: %v", err)
@@ -247,17 +521,15 @@ func (s *socket) handler(conn net.Conn) error {
 		return fmt.Errorf("empty message received")
 	}
 
-	split := strings.Split(message, " ")
+	// the command and the arguments are separated by white spaces.
+	split := strings.Fields(message)
+	if len(split) == 0 {
+		return fmt.Errorf("empty message received")
+	}
 
 	command := split[0]
 	args := split[1:]
 
-	// remove /t /n from command and arguments
-	command = strings.TrimSpace(command)
-	for i := range args {
-		args[i] = strings.TrimSpace(args[i])
-	}
-
 	log.Infof("Received command: %s with args: %v", command, args)
 
 	var res *SocketResponse
@@ -276,7 +548,25 @@ func (s *socket) handler(conn net.Conn) error {
 		res = s.restartService(args...)
 
 	case SocketActionStatus:
-		res = s.status()
+		res = s.status(args...)
+
+	case SocketActionHealth:
+		res = s.health()
+
+	case SocketActionMatch:
+		res = s.match(args...)
+
+	case SocketActionReloadService:
+		res = s.reloadService(args...)
+
+	case SocketActionSignalService:
+		res = s.signalService(args...)
+
+	case SocketActionStopServiceAsync, SocketActionRestartServiceAsync:
+		res = s.submitService(socketAction(command), args...)
+
+	case SocketActionOperation:
+		res = s.operation(args...)
 
 	default:
 		res = &SocketResponse{
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"reflect"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -134,21 +136,26 @@ func TestSocketRestartService(t *testing.T) {
 			name:    "Service restart success",
 			service: []string{"service1"},
 			setupMock: func(mockRunner *MockRunner) {
-				mockRunner.EXPECT().RestartService("service1").Return(nil).Times(1)
+				mockRunner.EXPECT().RestartServiceContext(gomock.Any(), "service1").
+					Return(ServiceResults{"service1": nil}).Times(1)
 			},
 			want: &SocketResponse{
-				Result: "service(s) restarted successfully: [service1]",
+				Result: map[string]ServiceOutcome{"service1": {Result: "restarted", Status: Success}},
 				Status: Success,
 			},
 		},
 		{
-			name:    "Service restart failure",
-			service: []string{"service1"},
+			name:    "Service restart partial failure",
+			service: []string{"service1", "service2"},
 			setupMock: func(mockRunner *MockRunner) {
-				mockRunner.EXPECT().RestartService("service1").Return(fmt.Errorf("failed to restart")).Times(1)
+				mockRunner.EXPECT().RestartServiceContext(gomock.Any(), "service1", "service2").
+					Return(ServiceResults{"service1": nil, "service2": ErrServiceNotFound}).Times(1)
 			},
 			want: &SocketResponse{
-				Result: "failed to restart service(s)- [service1]: failed to restart",
+				Result: map[string]ServiceOutcome{
+					"service1": {Result: "restarted", Status: Success},
+					"service2": {Result: "service not found", Status: Failure},
+				},
 				Status: Failure,
 			},
 		},
@@ -168,13 +175,165 @@ func TestSocketRestartService(t *testing.T) {
 			}
 
 			got := s.restartService(tt.service...)
-			if got.Result != tt.want.Result || got.Status != tt.want.Status {
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("restartService() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestSocketReloadService(t *testing.T) {
+	tests := []struct {
+		name      string
+		service   []string
+		setupMock func(mockRunner *MockRunner)
+		want      *SocketResponse
+	}{
+		{
+			name:      "No service name provided",
+			service:   []string{},
+			setupMock: func(mockRunner *MockRunner) {},
+			want: &SocketResponse{
+				Result: "no service name provided",
+				Status: Failure,
+			},
+		},
+		{
+			name:    "Service reload partial failure",
+			service: []string{"service1", "service2"},
+			setupMock: func(mockRunner *MockRunner) {
+				mockRunner.EXPECT().ReloadServiceContext(gomock.Any(), "service1", "service2").
+					Return(ServiceResults{"service1": nil, "service2": ErrReloadNotSupported}).Times(1)
+			},
+			want: &SocketResponse{
+				Result: map[string]ServiceOutcome{
+					"service1": {Result: "reloaded", Status: Success},
+					"service2": {Result: "reload not supported", Status: Failure},
+				},
+				Status: Failure,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRunner := NewMockRunner(ctrl)
+
+			tt.setupMock(mockRunner)
+
+			s := &socket{
+				r: mockRunner,
+			}
+
+			got := s.reloadService(tt.service...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reloadService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSignal(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    os.Signal
+		wantErr bool
+	}{
+		{name: "USR1", want: syscall.SIGUSR1},
+		{name: "SIGUSR2", want: syscall.SIGUSR2},
+		{name: "hup", want: syscall.SIGHUP},
+		{name: "10", want: syscall.Signal(10)},
+		{name: "BOGUS", wantErr: true},
+		{name: "-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSignal(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSignal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("parseSignal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSocketSignalService(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		setupMock func(mockRunner *MockRunner)
+		want      *SocketResponse
+	}{
+		{
+			name:      "Missing signal",
+			args:      []string{"service1"},
+			setupMock: func(mockRunner *MockRunner) {},
+			want: &SocketResponse{
+				Result: "usage: signal <service> <signal>",
+				Status: Failure,
+			},
+		},
+		{
+			name:      "Unknown signal",
+			args:      []string{"service1", "BOGUS"},
+			setupMock: func(mockRunner *MockRunner) {},
+			want: &SocketResponse{
+				Result: "unknown signal: BOGUS",
+				Status: Failure,
+			},
+		},
+		{
+			name: "Signal delivered",
+			args: []string{"service1", "USR1"},
+			setupMock: func(mockRunner *MockRunner) {
+				mockRunner.EXPECT().SignalService("service1", syscall.SIGUSR1).Return(nil).Times(1)
+			},
+			want: &SocketResponse{
+				Result: "signal user defined signal 1 delivered to service service1",
+				Status: Success,
+			},
+		},
+		{
+			name: "Signal not subscribed",
+			args: []string{"service1", "USR2"},
+			setupMock: func(mockRunner *MockRunner) {
+				mockRunner.EXPECT().SignalService("service1", syscall.SIGUSR2).Return(ErrSignalNotSubscribed).Times(1)
+			},
+			want: &SocketResponse{
+				Result: "signal not subscribed",
+				Status: Failure,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRunner := NewMockRunner(ctrl)
+
+			tt.setupMock(mockRunner)
+
+			s := &socket{
+				r: mockRunner,
+			}
+
+			got := s.signalService(tt.args...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("signalService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSocketStopService(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -195,21 +354,26 @@ func TestSocketStopService(t *testing.T) {
 			name:    "Service stop success",
 			service: []string{"service1"},
 			setupMock: func(mockRunner *MockRunner) {
-				mockRunner.EXPECT().StopService("service1").Return(nil).Times(1)
+				mockRunner.EXPECT().StopServiceContext(gomock.Any(), "service1").
+					Return(ServiceResults{"service1": nil}).Times(1)
 			},
 			want: &SocketResponse{
-				Result: "service(s) stopped successfully: [service1]",
+				Result: map[string]ServiceOutcome{"service1": {Result: "stopped", Status: Success}},
 				Status: Success,
 			},
 		},
 		{
-			name:    "Service stop failure",
-			service: []string{"service1"},
+			name:    "Service stop partial failure",
+			service: []string{"service1", "service2"},
 			setupMock: func(mockRunner *MockRunner) {
-				mockRunner.EXPECT().StopService("service1").Return(fmt.Errorf("failed to stop")).Times(1)
+				mockRunner.EXPECT().StopServiceContext(gomock.Any(), "service1", "service2").
+					Return(ServiceResults{"service1": nil, "service2": fmt.Errorf("failed to stop")}).Times(1)
 			},
 			want: &SocketResponse{
-				Result: "failed to stop service(s)- [service1]: failed to stop",
+				Result: map[string]ServiceOutcome{
+					"service1": {Result: "stopped", Status: Success},
+					"service2": {Result: "failed to stop", Status: Failure},
+				},
 				Status: Failure,
 			},
 		},
@@ -229,7 +393,7 @@ func TestSocketStopService(t *testing.T) {
 			}
 
 			got := s.stopService(tt.service...)
-			if got.Result != tt.want.Result || got.Status != tt.want.Status {
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("stopService() = %v, want %v", got, tt.want)
 			}
 		})
@@ -363,6 +527,174 @@ func TestSocketServiceStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestSocketServiceStatusTargets(t *testing.T) {
+	status := func() *RunnerStatus {
+		return &RunnerStatus{
+			IsRunning: true,
+			Services: map[string]ServiceInfo{
+				"ingest-1": {Status: ServiceStatusRunning},
+				"api":      {Status: ServiceStatusRunning},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		targets   []string
+		setupMock func(mockRunner *MockRunner)
+		want      *SocketResponse
+	}{
+		{
+			name:    "Selected services",
+			targets: []string{"tier=ingest"},
+			setupMock: func(mockRunner *MockRunner) {
+				mockRunner.EXPECT().Status().Return(status()).Times(1)
+				mockRunner.EXPECT().Resolve("tier=ingest").Return([]string{"ingest-1"}, nil).Times(1)
+			},
+			want: &SocketResponse{
+				Result: &RunnerStatus{
+					IsRunning: true,
+					Services:  map[string]ServiceInfo{"ingest-1": {Status: ServiceStatusRunning}},
+				},
+				Status: Success,
+			},
+		},
+		{
+			name:    "Selector without match",
+			targets: []string{"tier=db"},
+			setupMock: func(mockRunner *MockRunner) {
+				mockRunner.EXPECT().Status().Return(status()).Times(1)
+				mockRunner.EXPECT().Resolve("tier=db").Return([]string{}, ErrNoServiceMatched).Times(1)
+			},
+			want: &SocketResponse{
+				Result: ErrNoServiceMatched.Error(),
+				Status: Failure,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRunner := NewMockRunner(ctrl)
+
+			tt.setupMock(mockRunner)
+
+			s := &socket{
+				r: mockRunner,
+			}
+
+			if got := s.status(tt.targets...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("status() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSocketMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		targets   []string
+		setupMock func(mockRunner *MockRunner)
+		want      *SocketResponse
+	}{
+		{
+			name:    "Matched services",
+			targets: []string{"ingest.*", "tier=api"},
+			setupMock: func(mockRunner *MockRunner) {
+				mockRunner.EXPECT().Resolve("ingest.*", "tier=api").Return([]string{"api.http", "ingest.shard-1"}, nil).Times(1)
+			},
+			want: &SocketResponse{
+				Result: []string{"api.http", "ingest.shard-1"},
+				Status: Success,
+			},
+		},
+		{
+			name:    "Pattern without match",
+			targets: []string{"db.*"},
+			setupMock: func(mockRunner *MockRunner) {
+				mockRunner.EXPECT().Resolve("db.*").Return([]string{}, ErrNoServiceMatched).Times(1)
+			},
+			want: &SocketResponse{
+				Result: ErrNoServiceMatched.Error(),
+				Status: Failure,
+			},
+		},
+		{
+			name:      "No targets",
+			setupMock: func(mockRunner *MockRunner) {},
+			want: &SocketResponse{
+				Result: "no service name provided",
+				Status: Failure,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRunner := NewMockRunner(ctrl)
+
+			tt.setupMock(mockRunner)
+
+			s := &socket{
+				r: mockRunner,
+			}
+
+			if got := s.match(tt.targets...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSocketHealth(t *testing.T) {
+	tests := []struct {
+		name   string
+		health *Health
+		want   socketCommandStatus
+	}{
+		{
+			name:   "Healthy runner",
+			health: &Health{State: HealthHealthy},
+			want:   Success,
+		},
+		{
+			name:   "Degraded runner",
+			health: &Health{State: HealthDegraded, Reasons: []string{"service service1 is exited"}},
+			want:   Success,
+		},
+		{
+			name:   "Unhealthy runner",
+			health: &Health{State: HealthUnhealthy, Reasons: []string{"runner is not running"}},
+			want:   Failure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRunner := NewMockRunner(ctrl)
+			mockRunner.EXPECT().Health().Return(tt.health).Times(1)
+
+			s := &socket{
+				r: mockRunner,
+			}
+
+			got := s.health()
+			if !reflect.DeepEqual(got, &SocketResponse{Result: tt.health, Status: tt.want}) {
+				t.Errorf("health() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
 func TestSocketHandler(t *testing.T) {
 	// status := &RunnerStatus{
 	// 	IsRunning: true,
@@ -389,13 +721,17 @@ func TestSocketHandler(t *testing.T) {
 			},
 		},
 		{
-			name:    "Stop specific service",
-			command: "stop service1\n",
+			name:    "Stop specific services",
+			command: "stop service1 service2\n",
 			setupMock: func(mockRunner *MockRunner) {
-				mockRunner.EXPECT().StopService("service1").Return(nil).Times(1)
+				mockRunner.EXPECT().StopServiceContext(gomock.Any(), "service1", "service2").
+					Return(ServiceResults{"service1": nil, "service2": nil}).Times(1)
 			},
 			want: &SocketResponse{
-				Result: "service(s) stopped successfully: [service1]",
+				Result: map[string]interface{}{
+					"service1": map[string]interface{}{"result": "stopped", "status": "success"},
+					"service2": map[string]interface{}{"result": "stopped", "status": "success"},
+				},
 				Status: Success,
 			},
 		},
@@ -414,10 +750,13 @@ func TestSocketHandler(t *testing.T) {
 			name:    "Restart specific service",
 			command: "restart service1\n",
 			setupMock: func(mockRunner *MockRunner) {
-				mockRunner.EXPECT().RestartService("service1").Return(nil).Times(1)
+				mockRunner.EXPECT().RestartServiceContext(gomock.Any(), "service1").
+					Return(ServiceResults{"service1": nil}).Times(1)
 			},
 			want: &SocketResponse{
-				Result: "service(s) restarted successfully: [service1]",
+				Result: map[string]interface{}{
+					"service1": map[string]interface{}{"result": "restarted", "status": "success"},
+				},
 				Status: Success,
 			},
 		},
@@ -470,7 +809,7 @@ func TestSocketHandler(t *testing.T) {
 				t.Fatalf("unmarshal response error = %v", err)
 			}
 
-			if got.Result != tt.want.Result || got.Status != tt.want.Status {
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("handler() = %v, want %v", got, tt.want)
 			}
 		})
@@ -513,3 +852,141 @@ func (m *mockConn) SetReadDeadline(t time.Time) error {
 func (m *mockConn) SetWriteDeadline(t time.Time) error {
 	return nil
 }
+
+func TestSocketOperation(t *testing.T) {
+	op := &Operation{ID: "op1", State: OperationSucceeded}
+	failed := &Operation{ID: "op2", State: OperationFailed}
+
+	tests := []struct {
+		name      string
+		command   string
+		setupMock func(mockRunner *MockRunner)
+		want      *SocketResponse
+	}{
+		{
+			name:      "Submit without service name",
+			command:   "stopAsync\n",
+			setupMock: func(mockRunner *MockRunner) {},
+			want: &SocketResponse{
+				Result: "no service name provided",
+				Status: Failure,
+			},
+		},
+		{
+			name:    "Submit stop",
+			command: "stopAsync service1 service2\n",
+			setupMock: func(mockRunner *MockRunner) {
+				mockRunner.EXPECT().StopServiceAsync("service1", "service2").Return("op1").Times(1)
+			},
+			want: &SocketResponse{
+				Result: "op1",
+				Status: Success,
+			},
+		},
+		{
+			name:    "Submit restart",
+			command: "restartAsync service1\n",
+			setupMock: func(mockRunner *MockRunner) {
+				mockRunner.EXPECT().RestartServiceAsync("service1").Return("op1").Times(1)
+			},
+			want: &SocketResponse{
+				Result: "op1",
+				Status: Success,
+			},
+		},
+		{
+			name:    "Operation status",
+			command: "op status op2\n",
+			setupMock: func(mockRunner *MockRunner) {
+				mockRunner.EXPECT().Operation("op2").Return(failed, nil).Times(1)
+			},
+			want: &SocketResponse{
+				Result: map[string]interface{}{
+					"id": "op2", "action": "", "services": nil, "state": "failed",
+					"startTime": "0001-01-01T00:00:00Z", "endTime": "0001-01-01T00:00:00Z",
+				},
+				Status: Success,
+			},
+		},
+		{
+			name:    "Operation wait",
+			command: "op wait op1\n",
+			setupMock: func(mockRunner *MockRunner) {
+				mockRunner.EXPECT().WaitOperation(gomock.Any(), "op1").Return(op, nil).Times(1)
+			},
+			want: &SocketResponse{
+				Result: map[string]interface{}{
+					"id": "op1", "action": "", "services": nil, "state": "succeeded",
+					"startTime": "0001-01-01T00:00:00Z", "endTime": "0001-01-01T00:00:00Z",
+				},
+				Status: Success,
+			},
+		},
+		{
+			name:    "Operation wait for failed operation",
+			command: "op wait op2\n",
+			setupMock: func(mockRunner *MockRunner) {
+				mockRunner.EXPECT().WaitOperation(gomock.Any(), "op2").Return(failed, nil).Times(1)
+			},
+			want: &SocketResponse{
+				Result: map[string]interface{}{
+					"id": "op2", "action": "", "services": nil, "state": "failed",
+					"startTime": "0001-01-01T00:00:00Z", "endTime": "0001-01-01T00:00:00Z",
+				},
+				Status: Failure,
+			},
+		},
+		{
+			name:    "Unknown operation",
+			command: "op status op3\n",
+			setupMock: func(mockRunner *MockRunner) {
+				mockRunner.EXPECT().Operation("op3").Return(nil, ErrOperationNotFound).Times(1)
+			},
+			want: &SocketResponse{
+				Result: "operation not found",
+				Status: Failure,
+			},
+		},
+		{
+			name:      "Invalid operation command",
+			command:   "op op1\n",
+			setupMock: func(mockRunner *MockRunner) {},
+			want: &SocketResponse{
+				Result: "usage: op status|wait <id>",
+				Status: Failure,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRunner := NewMockRunner(ctrl)
+			tt.setupMock(mockRunner)
+
+			s := &socket{
+				r: mockRunner,
+			}
+
+			conn := &mockConn{
+				readBuffer:  strings.NewReader(tt.command),
+				writeBuffer: &strings.Builder{},
+			}
+
+			if err := s.handler(conn); err != nil {
+				t.Fatalf("handler() error = %v", err)
+			}
+
+			got := &SocketResponse{}
+			if err := json.Unmarshal([]byte(conn.writeBuffer.String()), &got); err != nil {
+				t.Fatalf("unmarshal response error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("handler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package glcm
 
-import "time"
+import (
+	"context"
+	"os"
+	"time"
+)
 
 //go:generate mockgen -package glcm -destination spec.mock.go -source spec.go -self_package "github.com/achu-1612/glcm"
 
@@ -15,6 +19,16 @@ type Hook interface {
 	Name() string
 }
 
+// Condition is an interface which represents a predicate for starting a service.
+// The conditions of a service are evaluated before each start attempt of the service.
+type Condition interface {
+	// Check returns nil if the condition is met, otherwise the reason as an error.
+	Check() error
+
+	// Name returns the name of the condition.
+	Name() string
+}
+
 // Service defines an interface which represents a single service and the
 // operations that can be performed on the service.
 // Note:
@@ -30,10 +44,90 @@ type Service interface {
 	Start(Terminator)
 }
 
+// Job is an optional interface for the oneshot services to report the outcome of a run.
+// A oneshot service which does not implement the interface, is considered successful once it returns.
+type Job interface {
+	// Result returns the result value of the last run, along with an error if the run has failed.
+	Result() (interface{}, error)
+}
+
+// Reloader is an optional interface for the services which can reload (e.g. their configuration)
+// without being restarted, so that the in-flight work is not dropped.
+// The running services are reloaded on SIGHUP by default, if any of the registered services implements it.
+type Reloader interface {
+	// Reload reloads the running service. The context bounds the time for the reload.
+	Reload(ctx context.Context) error
+}
+
+// StatusReporter is an optional interface for the services which report custom details about their state,
+// e.g. the depth of a queue or the connected peers. The details are included in the status of the service.
+type StatusReporter interface {
+	// StatusDetails returns the details as key/value pairs. It is called on every status request, so it must not block.
+	StatusDetails() map[string]interface{}
+}
+
+// HealthChecker is an optional interface for the services which can check their own health (e.g. a ping
+// to their dependencies). The running services are checked periodically and a failure affects the health of the runner.
+type HealthChecker interface {
+	// HealthCheck returns an error if the service is not healthy. The context bounds the time for the check.
+	HealthCheck(ctx context.Context) error
+}
+
 // Terminator defines an indicator to the service to stop.
 type Terminator interface {
 	// TermCh returns a channel which will be closed when the service should stop.
 	TermCh() chan struct{}
+
+	// Signals subscribes the service to the given process signals, for the current run of the service.
+	// It returns the channel on which the signals are delivered. A signal is dropped,
+	// if the previous one is not received yet.
+	Signals(sig ...os.Signal) <-chan os.Signal
+}
+
+// Clock is an interface which represents the source of time for the runner and the services.
+// It allows the time based behaviour (backoff, schedules, timeouts) to be tested with a fake clock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Since returns the time elapsed since the given time.
+	Since(time.Time) time.Duration
+
+	// Until returns the duration until the given time.
+	Until(time.Time) time.Duration
+
+	// After returns a channel on which the current time is sent after the given duration.
+	After(time.Duration) <-chan time.Time
+
+	// NewTimer returns a new timer which fires after the given duration.
+	NewTimer(time.Duration) Timer
+
+	// NewTicker returns a new ticker which fires at the given interval.
+	NewTicker(time.Duration) Ticker
+
+	// AfterFunc calls the given function after the given duration.
+	AfterFunc(time.Duration, func()) Timer
+}
+
+// Timer represents a single event timer of a Clock.
+type Timer interface {
+	// C returns the channel on which the time is sent when the timer fires.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing. It returns false if the timer has already fired or been stopped.
+	Stop() bool
+
+	// Reset changes the timer to fire after the given duration.
+	Reset(time.Duration) bool
+}
+
+// Ticker represents a periodic event timer of a Clock.
+type Ticker interface {
+	// C returns the channel on which the time is sent on every tick.
+	C() <-chan time.Time
+
+	// Stop turns off the ticker.
+	Stop()
 }
 
 // Runner represents the interface for the base runner methods.
@@ -44,29 +138,91 @@ type Runner interface {
 	// RegisterService registers a service with the runner.
 	RegisterService(Service, ServiceOptions) error
 
-	// DeregisterService deregisters a service from the runner.
+	// DeregisterService deregisters a service from the runner, by its exact name.
 	DeregisterService(string) error
 
+	// DeregisterServiceContext deregisters the specified services by their exact names, stopping them first if they are running.
+	// It returns the outcome for each of the services. The context bounds the wait for the services to stop.
+	DeregisterServiceContext(context.Context, ...string) ServiceResults
+
 	// Shutdown stops all the services and the runner.
+	// The runner can be booted up again after the shutdown.
 	Shutdown()
 
 	// StopAllServices stops all the services.
 	StopAllServices()
 
+	// Resolve returns the names of the services matched by the given targets.
+	// A target of a control operation is either the name of a service, a glob pattern (e.g. ingest.*),
+	// a regular expression prefixed with re: (e.g. re:^ingest\.shard-[0-9]+$) or a label selector (e.g. tier=ingest,env!=canary).
+	Resolve(...string) ([]string, error)
+
 	// StopService stops the specified services.
 	StopService(...string) error
 
+	// StopServiceContext stops the specified services and returns the outcome for each of them.
+	// The context bounds the wait for the services to stop.
+	StopServiceContext(context.Context, ...string) ServiceResults
+
 	// RestartService restarts the specified services.
 	RestartService(...string) error
 
+	// RestartServiceContext restarts the specified services and returns the outcome for each of them.
+	// The context bounds the wait for the services to stop, before they are started again.
+	RestartServiceContext(context.Context, ...string) ServiceResults
+
+	// SignalService delivers a synthetic signal to the given service, if the service has subscribed to it.
+	SignalService(string, os.Signal) error
+
+	// ReloadService reloads the given list of services.
+	ReloadService(...string) error
+
+	// ReloadServiceContext reloads the given list of services, till the context is done.
+	// It returns the outcome for each of the services.
+	ReloadServiceContext(context.Context, ...string) ServiceResults
+
+	// StopServiceAsync submits the stop of the specified services and returns the id of the operation.
+	StopServiceAsync(...string) string
+
+	// RestartServiceAsync submits the restart of the specified services and returns the id of the operation.
+	RestartServiceAsync(...string) string
+
+	// Operation returns the asynchronous operation with the given id.
+	// The recent operations are kept, the older ones are not found.
+	Operation(string) (*Operation, error)
+
+	// WaitOperation blocks till the asynchronous operation with the given id is finished, or the context is done.
+	WaitOperation(context.Context, string) (*Operation, error)
+
 	// RestartAllServices restarts all the services.
 	RestartAllServices()
 
-	// BootUp starts the runner.
+	// BootUp starts the runner and blocks till the runner is shut down.
+	// On every boot up, the idle services are reset and started again.
+	// It returns an error, if a critical service has taken the runner down.
 	BootUp() error
 
+	// Start starts the runner and returns once the services are started.
+	// The runner is shut down when the given context is done.
+	Start(context.Context) error
+
+	// Wait blocks till the runner is shut down and returns the reasons for which it has stopped.
+	Wait() error
+
+	// Done returns a channel which is closed once the runner is shut down.
+	Done() <-chan struct{}
+
 	// Status returns the status of the runner along with the status of each registered service.
 	Status() *RunnerStatus
+
+	// Health returns the overall health of the runner, computed from the status of the services.
+	Health() *Health
+
+	// WaitForStatus blocks till the given service is in one of the given statuses, or the context is done.
+	WaitForStatus(context.Context, string, ...ServiceStatus) error
+
+	// WaitForAll blocks till the given predicate on the runner status is true, or the context is done.
+	WaitForAll(context.Context, func(*RunnerStatus) bool) error
 }
 
 // Wrapper is an interface which represents the wraper around the service.
@@ -77,18 +233,81 @@ type Wrapper interface {
 	// Status returns the status of the service/wrapper.
 	Status() ServiceStatus
 
+	// History returns the recent status transitions of the service, oldest first.
+	History() []Transition
+
+	// Transition moves the service to the given status, if the transition is legal.
+	Transition(ServiceStatus, string) error
+
 	// TermCh returns the termination channel for the service.
 	TermCh() chan struct{}
 
+	// Signals subscribes the service to the given process signals.
+	Signals(...os.Signal) <-chan os.Signal
+
+	// Signal delivers the signal to the service, if the service has subscribed to it.
+	Signal(os.Signal) error
+
 	// Start starts the services in the wrapper.
 	Start()
 
 	// Stop stops the service in the wrapper and waits for the service to stop.
 	Stop()
 
-	// AutoRestart returns the auto-restart configuration for the wrapper.
+	// Reset moves an idle service back to the registered status, as on the first boot up of the runner.
+	Reset() error
+
+	// Reload reloads the running service, with a fallback to a restart if enabled for the service.
+	Reload(context.Context) error
+
+	// LastReload returns the outcome of the last reload of the service. nil if the service is never reloaded.
+	LastReload() *ReloadResult
+
+	// Reloadable returns true if the service implements the Reloader interface.
+	Reloadable() bool
+
+	// CheckHealth runs the health check of the running service, if the service implements the HealthChecker interface.
+	CheckHealth(context.Context) error
+
+	// LastHealthCheck returns the outcome of the last health check of the service. nil if the service is never checked.
+	LastHealthCheck() *HealthCheckResult
+
+	// AutoRestart returns a copy of the auto-restart configuration for the wrapper, along with the current number of retries.
+	// Changing the copy does not change the wrapper.
 	AutoRestart() *AutoRestart
 
+	// Retries returns the current number of retries for the service.
+	Retries() int
+
+	// IncRetry increments the number of retries for the service and returns the new count.
+	IncRetry() int
+
+	// Critical returns true if the runner is shut down, when the service fails for good.
+	Critical() bool
+
+	// Metadata returns the descriptive information (description, owner, version and labels) of the service.
+	Metadata() ServiceMetadata
+
 	// Uptime returns the uptime of the service.
 	Uptime() time.Duration
+
+	// Stats returns the counters and the timings of the runs of the service.
+	Stats() ServiceStats
+
+	// Result returns the result value of the last successful run of a oneshot service.
+	Result() interface{}
+
+	// Details returns the custom details reported by the service. nil if the service is not a StatusReporter.
+	Details() map[string]interface{}
+
+	// Runs returns the run statistics of a periodic service. nil if the service is not periodic.
+	Runs() *RunStats
+
+	// InActiveWindow returns true if the service is with in one of its active windows.
+	// It always returns true for the services without active windows.
+	InActiveWindow() bool
+
+	// NextTransition returns the time at which the current active window of the service closes
+	// or the next one opens. A zero time is returned for the services without active windows.
+	NextTransition() time.Time
 }
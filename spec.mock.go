@@ -5,6 +5,8 @@
 package glcm
 
 import (
+	context "context"
+	os "os"
 	reflect "reflect"
 	time "time"
 
@@ -62,6 +64,57 @@ func (mr *MockHookMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockHook)(nil).Name))
 }
 
+// MockCondition is a mock of Condition interface.
+type MockCondition struct {
+	ctrl     *gomock.Controller
+	recorder *MockConditionMockRecorder
+}
+
+// MockConditionMockRecorder is the mock recorder for MockCondition.
+type MockConditionMockRecorder struct {
+	mock *MockCondition
+}
+
+// NewMockCondition creates a new mock instance.
+func NewMockCondition(ctrl *gomock.Controller) *MockCondition {
+	mock := &MockCondition{ctrl: ctrl}
+	mock.recorder = &MockConditionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCondition) EXPECT() *MockConditionMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockCondition) Check() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockConditionMockRecorder) Check() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockCondition)(nil).Check))
+}
+
+// Name mocks base method.
+func (m *MockCondition) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockConditionMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockCondition)(nil).Name))
+}
+
 // MockService is a mock of Service interface.
 type MockService struct {
 	ctrl     *gomock.Controller
@@ -111,6 +164,155 @@ func (mr *MockServiceMockRecorder) Start(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockService)(nil).Start), arg0)
 }
 
+// MockJob is a mock of Job interface.
+type MockJob struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobMockRecorder
+}
+
+// MockJobMockRecorder is the mock recorder for MockJob.
+type MockJobMockRecorder struct {
+	mock *MockJob
+}
+
+// NewMockJob creates a new mock instance.
+func NewMockJob(ctrl *gomock.Controller) *MockJob {
+	mock := &MockJob{ctrl: ctrl}
+	mock.recorder = &MockJobMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJob) EXPECT() *MockJobMockRecorder {
+	return m.recorder
+}
+
+// Result mocks base method.
+func (m *MockJob) Result() (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Result")
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Result indicates an expected call of Result.
+func (mr *MockJobMockRecorder) Result() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Result", reflect.TypeOf((*MockJob)(nil).Result))
+}
+
+// MockReloader is a mock of Reloader interface.
+type MockReloader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReloaderMockRecorder
+}
+
+// MockReloaderMockRecorder is the mock recorder for MockReloader.
+type MockReloaderMockRecorder struct {
+	mock *MockReloader
+}
+
+// NewMockReloader creates a new mock instance.
+func NewMockReloader(ctrl *gomock.Controller) *MockReloader {
+	mock := &MockReloader{ctrl: ctrl}
+	mock.recorder = &MockReloaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReloader) EXPECT() *MockReloaderMockRecorder {
+	return m.recorder
+}
+
+// Reload mocks base method.
+func (m *MockReloader) Reload(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reload", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reload indicates an expected call of Reload.
+func (mr *MockReloaderMockRecorder) Reload(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reload", reflect.TypeOf((*MockReloader)(nil).Reload), ctx)
+}
+
+// MockStatusReporter is a mock of StatusReporter interface.
+type MockStatusReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusReporterMockRecorder
+}
+
+// MockStatusReporterMockRecorder is the mock recorder for MockStatusReporter.
+type MockStatusReporterMockRecorder struct {
+	mock *MockStatusReporter
+}
+
+// NewMockStatusReporter creates a new mock instance.
+func NewMockStatusReporter(ctrl *gomock.Controller) *MockStatusReporter {
+	mock := &MockStatusReporter{ctrl: ctrl}
+	mock.recorder = &MockStatusReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusReporter) EXPECT() *MockStatusReporterMockRecorder {
+	return m.recorder
+}
+
+// StatusDetails mocks base method.
+func (m *MockStatusReporter) StatusDetails() map[string]interface{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatusDetails")
+	ret0, _ := ret[0].(map[string]interface{})
+	return ret0
+}
+
+// StatusDetails indicates an expected call of StatusDetails.
+func (mr *MockStatusReporterMockRecorder) StatusDetails() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusDetails", reflect.TypeOf((*MockStatusReporter)(nil).StatusDetails))
+}
+
+// MockHealthChecker is a mock of HealthChecker interface.
+type MockHealthChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthCheckerMockRecorder
+}
+
+// MockHealthCheckerMockRecorder is the mock recorder for MockHealthChecker.
+type MockHealthCheckerMockRecorder struct {
+	mock *MockHealthChecker
+}
+
+// NewMockHealthChecker creates a new mock instance.
+func NewMockHealthChecker(ctrl *gomock.Controller) *MockHealthChecker {
+	mock := &MockHealthChecker{ctrl: ctrl}
+	mock.recorder = &MockHealthCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthChecker) EXPECT() *MockHealthCheckerMockRecorder {
+	return m.recorder
+}
+
+// HealthCheck mocks base method.
+func (m *MockHealthChecker) HealthCheck(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HealthCheck", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HealthCheck indicates an expected call of HealthCheck.
+func (mr *MockHealthCheckerMockRecorder) HealthCheck(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockHealthChecker)(nil).HealthCheck), ctx)
+}
+
 // MockTerminator is a mock of Terminator interface.
 type MockTerminator struct {
 	ctrl     *gomock.Controller
@@ -134,6 +336,24 @@ func (m *MockTerminator) EXPECT() *MockTerminatorMockRecorder {
 	return m.recorder
 }
 
+// Signals mocks base method.
+func (m *MockTerminator) Signals(sig ...os.Signal) <-chan os.Signal {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range sig {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Signals", varargs...)
+	ret0, _ := ret[0].(<-chan os.Signal)
+	return ret0
+}
+
+// Signals indicates an expected call of Signals.
+func (mr *MockTerminatorMockRecorder) Signals(sig ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signals", reflect.TypeOf((*MockTerminator)(nil).Signals), sig...)
+}
+
 // TermCh mocks base method.
 func (m *MockTerminator) TermCh() chan struct{} {
 	m.ctrl.T.Helper()
@@ -148,6 +368,241 @@ func (mr *MockTerminatorMockRecorder) TermCh() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TermCh", reflect.TypeOf((*MockTerminator)(nil).TermCh))
 }
 
+// MockClock is a mock of Clock interface.
+type MockClock struct {
+	ctrl     *gomock.Controller
+	recorder *MockClockMockRecorder
+}
+
+// MockClockMockRecorder is the mock recorder for MockClock.
+type MockClockMockRecorder struct {
+	mock *MockClock
+}
+
+// NewMockClock creates a new mock instance.
+func NewMockClock(ctrl *gomock.Controller) *MockClock {
+	mock := &MockClock{ctrl: ctrl}
+	mock.recorder = &MockClockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClock) EXPECT() *MockClockMockRecorder {
+	return m.recorder
+}
+
+// After mocks base method.
+func (m *MockClock) After(arg0 time.Duration) <-chan time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "After", arg0)
+	ret0, _ := ret[0].(<-chan time.Time)
+	return ret0
+}
+
+// After indicates an expected call of After.
+func (mr *MockClockMockRecorder) After(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "After", reflect.TypeOf((*MockClock)(nil).After), arg0)
+}
+
+// AfterFunc mocks base method.
+func (m *MockClock) AfterFunc(arg0 time.Duration, arg1 func()) Timer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AfterFunc", arg0, arg1)
+	ret0, _ := ret[0].(Timer)
+	return ret0
+}
+
+// AfterFunc indicates an expected call of AfterFunc.
+func (mr *MockClockMockRecorder) AfterFunc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AfterFunc", reflect.TypeOf((*MockClock)(nil).AfterFunc), arg0, arg1)
+}
+
+// NewTicker mocks base method.
+func (m *MockClock) NewTicker(arg0 time.Duration) Ticker {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewTicker", arg0)
+	ret0, _ := ret[0].(Ticker)
+	return ret0
+}
+
+// NewTicker indicates an expected call of NewTicker.
+func (mr *MockClockMockRecorder) NewTicker(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTicker", reflect.TypeOf((*MockClock)(nil).NewTicker), arg0)
+}
+
+// NewTimer mocks base method.
+func (m *MockClock) NewTimer(arg0 time.Duration) Timer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewTimer", arg0)
+	ret0, _ := ret[0].(Timer)
+	return ret0
+}
+
+// NewTimer indicates an expected call of NewTimer.
+func (mr *MockClockMockRecorder) NewTimer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTimer", reflect.TypeOf((*MockClock)(nil).NewTimer), arg0)
+}
+
+// Now mocks base method.
+func (m *MockClock) Now() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Now")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// Now indicates an expected call of Now.
+func (mr *MockClockMockRecorder) Now() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Now", reflect.TypeOf((*MockClock)(nil).Now))
+}
+
+// Since mocks base method.
+func (m *MockClock) Since(arg0 time.Time) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Since", arg0)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// Since indicates an expected call of Since.
+func (mr *MockClockMockRecorder) Since(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Since", reflect.TypeOf((*MockClock)(nil).Since), arg0)
+}
+
+// Until mocks base method.
+func (m *MockClock) Until(arg0 time.Time) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Until", arg0)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// Until indicates an expected call of Until.
+func (mr *MockClockMockRecorder) Until(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Until", reflect.TypeOf((*MockClock)(nil).Until), arg0)
+}
+
+// MockTimer is a mock of Timer interface.
+type MockTimer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimerMockRecorder
+}
+
+// MockTimerMockRecorder is the mock recorder for MockTimer.
+type MockTimerMockRecorder struct {
+	mock *MockTimer
+}
+
+// NewMockTimer creates a new mock instance.
+func NewMockTimer(ctrl *gomock.Controller) *MockTimer {
+	mock := &MockTimer{ctrl: ctrl}
+	mock.recorder = &MockTimerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimer) EXPECT() *MockTimerMockRecorder {
+	return m.recorder
+}
+
+// C mocks base method.
+func (m *MockTimer) C() <-chan time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "C")
+	ret0, _ := ret[0].(<-chan time.Time)
+	return ret0
+}
+
+// C indicates an expected call of C.
+func (mr *MockTimerMockRecorder) C() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "C", reflect.TypeOf((*MockTimer)(nil).C))
+}
+
+// Reset mocks base method.
+func (m *MockTimer) Reset(arg0 time.Duration) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockTimerMockRecorder) Reset(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockTimer)(nil).Reset), arg0)
+}
+
+// Stop mocks base method.
+func (m *MockTimer) Stop() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockTimerMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockTimer)(nil).Stop))
+}
+
+// MockTicker is a mock of Ticker interface.
+type MockTicker struct {
+	ctrl     *gomock.Controller
+	recorder *MockTickerMockRecorder
+}
+
+// MockTickerMockRecorder is the mock recorder for MockTicker.
+type MockTickerMockRecorder struct {
+	mock *MockTicker
+}
+
+// NewMockTicker creates a new mock instance.
+func NewMockTicker(ctrl *gomock.Controller) *MockTicker {
+	mock := &MockTicker{ctrl: ctrl}
+	mock.recorder = &MockTickerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTicker) EXPECT() *MockTickerMockRecorder {
+	return m.recorder
+}
+
+// C mocks base method.
+func (m *MockTicker) C() <-chan time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "C")
+	ret0, _ := ret[0].(<-chan time.Time)
+	return ret0
+}
+
+// C indicates an expected call of C.
+func (mr *MockTickerMockRecorder) C() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "C", reflect.TypeOf((*MockTicker)(nil).C))
+}
+
+// Stop mocks base method.
+func (m *MockTicker) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockTickerMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockTicker)(nil).Stop))
+}
+
 // MockRunner is a mock of Runner interface.
 type MockRunner struct {
 	ctrl     *gomock.Controller
@@ -199,6 +654,53 @@ func (mr *MockRunnerMockRecorder) DeregisterService(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterService", reflect.TypeOf((*MockRunner)(nil).DeregisterService), arg0)
 }
 
+// DeregisterServiceContext mocks base method.
+func (m *MockRunner) DeregisterServiceContext(arg0 context.Context, arg1 ...string) ServiceResults {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeregisterServiceContext", varargs...)
+	ret0, _ := ret[0].(ServiceResults)
+	return ret0
+}
+
+// DeregisterServiceContext indicates an expected call of DeregisterServiceContext.
+func (mr *MockRunnerMockRecorder) DeregisterServiceContext(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterServiceContext", reflect.TypeOf((*MockRunner)(nil).DeregisterServiceContext), varargs...)
+}
+
+// Done mocks base method.
+func (m *MockRunner) Done() <-chan struct{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Done")
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
+}
+
+// Done indicates an expected call of Done.
+func (mr *MockRunnerMockRecorder) Done() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Done", reflect.TypeOf((*MockRunner)(nil).Done))
+}
+
+// Health mocks base method.
+func (m *MockRunner) Health() *Health {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health")
+	ret0, _ := ret[0].(*Health)
+	return ret0
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockRunnerMockRecorder) Health() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockRunner)(nil).Health))
+}
+
 // IsRunning mocks base method.
 func (m *MockRunner) IsRunning() bool {
 	m.ctrl.T.Helper()
@@ -213,18 +715,89 @@ func (mr *MockRunnerMockRecorder) IsRunning() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRunning", reflect.TypeOf((*MockRunner)(nil).IsRunning))
 }
 
-// RegisterService mocks base method.
-func (m *MockRunner) RegisterService(arg0 Service, arg1 ServiceOptions) error {
+// Operation mocks base method.
+func (m *MockRunner) Operation(arg0 string) (*Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Operation", arg0)
+	ret0, _ := ret[0].(*Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Operation indicates an expected call of Operation.
+func (mr *MockRunnerMockRecorder) Operation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Operation", reflect.TypeOf((*MockRunner)(nil).Operation), arg0)
+}
+
+// RegisterService mocks base method.
+func (m *MockRunner) RegisterService(arg0 Service, arg1 ServiceOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterService", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterService indicates an expected call of RegisterService.
+func (mr *MockRunnerMockRecorder) RegisterService(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterService", reflect.TypeOf((*MockRunner)(nil).RegisterService), arg0, arg1)
+}
+
+// ReloadService mocks base method.
+func (m *MockRunner) ReloadService(arg0 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReloadService", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReloadService indicates an expected call of ReloadService.
+func (mr *MockRunnerMockRecorder) ReloadService(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadService", reflect.TypeOf((*MockRunner)(nil).ReloadService), arg0...)
+}
+
+// ReloadServiceContext mocks base method.
+func (m *MockRunner) ReloadServiceContext(arg0 context.Context, arg1 ...string) ServiceResults {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReloadServiceContext", varargs...)
+	ret0, _ := ret[0].(ServiceResults)
+	return ret0
+}
+
+// ReloadServiceContext indicates an expected call of ReloadServiceContext.
+func (mr *MockRunnerMockRecorder) ReloadServiceContext(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadServiceContext", reflect.TypeOf((*MockRunner)(nil).ReloadServiceContext), varargs...)
+}
+
+// Resolve mocks base method.
+func (m *MockRunner) Resolve(arg0 ...string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterService", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Resolve", varargs...)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RegisterService indicates an expected call of RegisterService.
-func (mr *MockRunnerMockRecorder) RegisterService(arg0, arg1 interface{}) *gomock.Call {
+// Resolve indicates an expected call of Resolve.
+func (mr *MockRunnerMockRecorder) Resolve(arg0 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterService", reflect.TypeOf((*MockRunner)(nil).RegisterService), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockRunner)(nil).Resolve), arg0...)
 }
 
 // RestartAllServices mocks base method.
@@ -257,6 +830,43 @@ func (mr *MockRunnerMockRecorder) RestartService(arg0 ...interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestartService", reflect.TypeOf((*MockRunner)(nil).RestartService), arg0...)
 }
 
+// RestartServiceAsync mocks base method.
+func (m *MockRunner) RestartServiceAsync(arg0 ...string) string {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestartServiceAsync", varargs...)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// RestartServiceAsync indicates an expected call of RestartServiceAsync.
+func (mr *MockRunnerMockRecorder) RestartServiceAsync(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestartServiceAsync", reflect.TypeOf((*MockRunner)(nil).RestartServiceAsync), arg0...)
+}
+
+// RestartServiceContext mocks base method.
+func (m *MockRunner) RestartServiceContext(arg0 context.Context, arg1 ...string) ServiceResults {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestartServiceContext", varargs...)
+	ret0, _ := ret[0].(ServiceResults)
+	return ret0
+}
+
+// RestartServiceContext indicates an expected call of RestartServiceContext.
+func (mr *MockRunnerMockRecorder) RestartServiceContext(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestartServiceContext", reflect.TypeOf((*MockRunner)(nil).RestartServiceContext), varargs...)
+}
+
 // Shutdown mocks base method.
 func (m *MockRunner) Shutdown() {
 	m.ctrl.T.Helper()
@@ -269,6 +879,34 @@ func (mr *MockRunnerMockRecorder) Shutdown() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockRunner)(nil).Shutdown))
 }
 
+// SignalService mocks base method.
+func (m *MockRunner) SignalService(arg0 string, arg1 os.Signal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignalService", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignalService indicates an expected call of SignalService.
+func (mr *MockRunnerMockRecorder) SignalService(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignalService", reflect.TypeOf((*MockRunner)(nil).SignalService), arg0, arg1)
+}
+
+// Start mocks base method.
+func (m *MockRunner) Start(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockRunnerMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockRunner)(nil).Start), arg0)
+}
+
 // Status mocks base method.
 func (m *MockRunner) Status() *RunnerStatus {
 	m.ctrl.T.Helper()
@@ -313,6 +951,105 @@ func (mr *MockRunnerMockRecorder) StopService(arg0 ...interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopService", reflect.TypeOf((*MockRunner)(nil).StopService), arg0...)
 }
 
+// StopServiceAsync mocks base method.
+func (m *MockRunner) StopServiceAsync(arg0 ...string) string {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StopServiceAsync", varargs...)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// StopServiceAsync indicates an expected call of StopServiceAsync.
+func (mr *MockRunnerMockRecorder) StopServiceAsync(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopServiceAsync", reflect.TypeOf((*MockRunner)(nil).StopServiceAsync), arg0...)
+}
+
+// StopServiceContext mocks base method.
+func (m *MockRunner) StopServiceContext(arg0 context.Context, arg1 ...string) ServiceResults {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StopServiceContext", varargs...)
+	ret0, _ := ret[0].(ServiceResults)
+	return ret0
+}
+
+// StopServiceContext indicates an expected call of StopServiceContext.
+func (mr *MockRunnerMockRecorder) StopServiceContext(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopServiceContext", reflect.TypeOf((*MockRunner)(nil).StopServiceContext), varargs...)
+}
+
+// Wait mocks base method.
+func (m *MockRunner) Wait() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wait")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockRunnerMockRecorder) Wait() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockRunner)(nil).Wait))
+}
+
+// WaitForAll mocks base method.
+func (m *MockRunner) WaitForAll(arg0 context.Context, arg1 func(*RunnerStatus) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForAll", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForAll indicates an expected call of WaitForAll.
+func (mr *MockRunnerMockRecorder) WaitForAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForAll", reflect.TypeOf((*MockRunner)(nil).WaitForAll), arg0, arg1)
+}
+
+// WaitForStatus mocks base method.
+func (m *MockRunner) WaitForStatus(arg0 context.Context, arg1 string, arg2 ...ServiceStatus) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitForStatus", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForStatus indicates an expected call of WaitForStatus.
+func (mr *MockRunnerMockRecorder) WaitForStatus(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForStatus", reflect.TypeOf((*MockRunner)(nil).WaitForStatus), varargs...)
+}
+
+// WaitOperation mocks base method.
+func (m *MockRunner) WaitOperation(arg0 context.Context, arg1 string) (*Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitOperation", arg0, arg1)
+	ret0, _ := ret[0].(*Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitOperation indicates an expected call of WaitOperation.
+func (mr *MockRunnerMockRecorder) WaitOperation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitOperation", reflect.TypeOf((*MockRunner)(nil).WaitOperation), arg0, arg1)
+}
+
 // MockWrapper is a mock of Wrapper interface.
 type MockWrapper struct {
 	ctrl     *gomock.Controller
@@ -350,6 +1087,132 @@ func (mr *MockWrapperMockRecorder) AutoRestart() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoRestart", reflect.TypeOf((*MockWrapper)(nil).AutoRestart))
 }
 
+// CheckHealth mocks base method.
+func (m *MockWrapper) CheckHealth(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckHealth", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckHealth indicates an expected call of CheckHealth.
+func (mr *MockWrapperMockRecorder) CheckHealth(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckHealth", reflect.TypeOf((*MockWrapper)(nil).CheckHealth), arg0)
+}
+
+// Critical mocks base method.
+func (m *MockWrapper) Critical() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Critical")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Critical indicates an expected call of Critical.
+func (mr *MockWrapperMockRecorder) Critical() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Critical", reflect.TypeOf((*MockWrapper)(nil).Critical))
+}
+
+// Details mocks base method.
+func (m *MockWrapper) Details() map[string]interface{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Details")
+	ret0, _ := ret[0].(map[string]interface{})
+	return ret0
+}
+
+// Details indicates an expected call of Details.
+func (mr *MockWrapperMockRecorder) Details() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Details", reflect.TypeOf((*MockWrapper)(nil).Details))
+}
+
+// History mocks base method.
+func (m *MockWrapper) History() []Transition {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History")
+	ret0, _ := ret[0].([]Transition)
+	return ret0
+}
+
+// History indicates an expected call of History.
+func (mr *MockWrapperMockRecorder) History() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockWrapper)(nil).History))
+}
+
+// InActiveWindow mocks base method.
+func (m *MockWrapper) InActiveWindow() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InActiveWindow")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// InActiveWindow indicates an expected call of InActiveWindow.
+func (mr *MockWrapperMockRecorder) InActiveWindow() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InActiveWindow", reflect.TypeOf((*MockWrapper)(nil).InActiveWindow))
+}
+
+// IncRetry mocks base method.
+func (m *MockWrapper) IncRetry() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncRetry")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// IncRetry indicates an expected call of IncRetry.
+func (mr *MockWrapperMockRecorder) IncRetry() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncRetry", reflect.TypeOf((*MockWrapper)(nil).IncRetry))
+}
+
+// LastHealthCheck mocks base method.
+func (m *MockWrapper) LastHealthCheck() *HealthCheckResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastHealthCheck")
+	ret0, _ := ret[0].(*HealthCheckResult)
+	return ret0
+}
+
+// LastHealthCheck indicates an expected call of LastHealthCheck.
+func (mr *MockWrapperMockRecorder) LastHealthCheck() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastHealthCheck", reflect.TypeOf((*MockWrapper)(nil).LastHealthCheck))
+}
+
+// LastReload mocks base method.
+func (m *MockWrapper) LastReload() *ReloadResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastReload")
+	ret0, _ := ret[0].(*ReloadResult)
+	return ret0
+}
+
+// LastReload indicates an expected call of LastReload.
+func (mr *MockWrapperMockRecorder) LastReload() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastReload", reflect.TypeOf((*MockWrapper)(nil).LastReload))
+}
+
+// Metadata mocks base method.
+func (m *MockWrapper) Metadata() ServiceMetadata {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Metadata")
+	ret0, _ := ret[0].(ServiceMetadata)
+	return ret0
+}
+
+// Metadata indicates an expected call of Metadata.
+func (mr *MockWrapperMockRecorder) Metadata() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metadata", reflect.TypeOf((*MockWrapper)(nil).Metadata))
+}
+
 // Name mocks base method.
 func (m *MockWrapper) Name() string {
 	m.ctrl.T.Helper()
@@ -364,6 +1227,136 @@ func (mr *MockWrapperMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockWrapper)(nil).Name))
 }
 
+// NextTransition mocks base method.
+func (m *MockWrapper) NextTransition() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextTransition")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// NextTransition indicates an expected call of NextTransition.
+func (mr *MockWrapperMockRecorder) NextTransition() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextTransition", reflect.TypeOf((*MockWrapper)(nil).NextTransition))
+}
+
+// Reload mocks base method.
+func (m *MockWrapper) Reload(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reload", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reload indicates an expected call of Reload.
+func (mr *MockWrapperMockRecorder) Reload(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reload", reflect.TypeOf((*MockWrapper)(nil).Reload), arg0)
+}
+
+// Reloadable mocks base method.
+func (m *MockWrapper) Reloadable() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reloadable")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Reloadable indicates an expected call of Reloadable.
+func (mr *MockWrapperMockRecorder) Reloadable() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reloadable", reflect.TypeOf((*MockWrapper)(nil).Reloadable))
+}
+
+// Reset mocks base method.
+func (m *MockWrapper) Reset() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockWrapperMockRecorder) Reset() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockWrapper)(nil).Reset))
+}
+
+// Result mocks base method.
+func (m *MockWrapper) Result() interface{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Result")
+	ret0, _ := ret[0].(interface{})
+	return ret0
+}
+
+// Result indicates an expected call of Result.
+func (mr *MockWrapperMockRecorder) Result() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Result", reflect.TypeOf((*MockWrapper)(nil).Result))
+}
+
+// Retries mocks base method.
+func (m *MockWrapper) Retries() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retries")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Retries indicates an expected call of Retries.
+func (mr *MockWrapperMockRecorder) Retries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retries", reflect.TypeOf((*MockWrapper)(nil).Retries))
+}
+
+// Runs mocks base method.
+func (m *MockWrapper) Runs() *RunStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Runs")
+	ret0, _ := ret[0].(*RunStats)
+	return ret0
+}
+
+// Runs indicates an expected call of Runs.
+func (mr *MockWrapperMockRecorder) Runs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Runs", reflect.TypeOf((*MockWrapper)(nil).Runs))
+}
+
+// Signal mocks base method.
+func (m *MockWrapper) Signal(arg0 os.Signal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Signal", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Signal indicates an expected call of Signal.
+func (mr *MockWrapperMockRecorder) Signal(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signal", reflect.TypeOf((*MockWrapper)(nil).Signal), arg0)
+}
+
+// Signals mocks base method.
+func (m *MockWrapper) Signals(arg0 ...os.Signal) <-chan os.Signal {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Signals", varargs...)
+	ret0, _ := ret[0].(<-chan os.Signal)
+	return ret0
+}
+
+// Signals indicates an expected call of Signals.
+func (mr *MockWrapperMockRecorder) Signals(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signals", reflect.TypeOf((*MockWrapper)(nil).Signals), arg0...)
+}
+
 // Start mocks base method.
 func (m *MockWrapper) Start() {
 	m.ctrl.T.Helper()
@@ -376,6 +1369,20 @@ func (mr *MockWrapperMockRecorder) Start() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockWrapper)(nil).Start))
 }
 
+// Stats mocks base method.
+func (m *MockWrapper) Stats() ServiceStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(ServiceStats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockWrapperMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockWrapper)(nil).Stats))
+}
+
 // Status mocks base method.
 func (m *MockWrapper) Status() ServiceStatus {
 	m.ctrl.T.Helper()
@@ -416,6 +1423,20 @@ func (mr *MockWrapperMockRecorder) TermCh() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TermCh", reflect.TypeOf((*MockWrapper)(nil).TermCh))
 }
 
+// Transition mocks base method.
+func (m *MockWrapper) Transition(arg0 ServiceStatus, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockWrapperMockRecorder) Transition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockWrapper)(nil).Transition), arg0, arg1)
+}
+
 // Uptime mocks base method.
 func (m *MockWrapper) Uptime() time.Duration {
 	m.ctrl.T.Helper()
@@ -0,0 +1,99 @@
+package glcm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ScheduleState represents the persisted schedule state of a service.
+type ScheduleState struct {
+	LastRun time.Time `json:"lastRun"`
+	NextRun time.Time `json:"nextRun"`
+}
+
+// stateStore persists the schedule state of the services in a file,
+// so that a restarted runner knows the runs it has missed.
+type stateStore struct {
+	// path is the path to the state file.
+	path string
+
+	// mu is a mutex to protect the state.
+	mu *sync.Mutex
+
+	// state is the schedule state of the services by name.
+	state map[string]ScheduleState
+}
+
+// newStateStore returns a new instance of the state store.
+// The existing state is loaded from the given file, if present.
+func newStateStore(path string) (*stateStore, error) {
+	s := &stateStore{
+		path:  path,
+		mu:    &sync.Mutex{},
+		state: make(map[string]ScheduleState),
+	}
+
+	b, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading state file: %w", err)
+	}
+
+	if err := json.Unmarshal(b, &s.state); err != nil {
+		return nil, fmt.Errorf("parsing state file: %w", err)
+	}
+
+	return s, nil
+}
+
+// get returns the schedule state of the given service.
+func (s *stateStore) get(name string) (ScheduleState, bool) {
+	if s == nil {
+		return ScheduleState{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.state[name]
+
+	return st, ok
+}
+
+// set stores the schedule state of the given service and writes the state file.
+func (s *stateStore) set(name string, st ScheduleState) error {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state[name] = st
+
+	b, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal state: %w", err)
+	}
+
+	// write to a temporary file first, so that a crash does not leave a partial state file.
+	tmp := s.path + ".tmp"
+
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return fmt.Errorf("writing state file: %w", err)
+	}
+
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("replacing state file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package glcm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	s, err := newStateStore(path)
+	assert.Nil(t, err, "Expected no error for missing state file")
+
+	_, ok := s.get("service1")
+	assert.False(t, ok, "Expected no state for unknown service")
+
+	want := ScheduleState{
+		LastRun: time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+		NextRun: time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC),
+	}
+
+	assert.Nil(t, s.set("service1", want), "Expected no error for persisting the state")
+
+	// a new store should load the persisted state.
+	s, err = newStateStore(path)
+	assert.Nil(t, err, "Expected no error for loading the state file")
+
+	got, ok := s.get("service1")
+	assert.True(t, ok, "Expected state for persisted service")
+	assert.True(t, want.LastRun.Equal(got.LastRun), "Expected last run to be restored")
+	assert.True(t, want.NextRun.Equal(got.NextRun), "Expected next run to be restored")
+}
+
+func TestStateStoreInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	assert.Nil(t, os.WriteFile(path, []byte("invalid"), 0600))
+
+	_, err := newStateStore(path)
+	assert.NotNil(t, err, "Expected error for invalid state file")
+}
+
+func TestNilStateStore(t *testing.T) {
+	var s *stateStore
+
+	_, ok := s.get("service1")
+	assert.False(t, ok, "Expected no state from nil store")
+	assert.Nil(t, s.set("service1", ScheduleState{}), "Expected no error from nil store")
+}
@@ -0,0 +1,166 @@
+package glcm
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Transition represents a change in the status of a service.
+type Transition struct {
+	From   ServiceStatus `json:"from"`
+	To     ServiceStatus `json:"to"`
+	Time   time.Time     `json:"time"`
+	Reason string        `json:"reason"`
+}
+
+// idle are the statuses from which a service can be started.
+var idle = []ServiceStatus{
+	ServiceStatusRegistered,
+	ServiceStatusConditionUnmet,
+	ServiceStatusOutOfWindow,
+	ServiceStatusExited,
+	ServiceStatusStopped,
+	ServiceStatusScheduledForRestart,
+	ServiceStatusExhausted,
+	ServiceStatusCompleted,
+}
+
+// transitions are the legal transitions between the statuses of a service.
+var transitions = map[ServiceStatus][]ServiceStatus{
+	ServiceStatusPendingStart: {ServiceStatusStarting, ServiceStatusStopped, ServiceStatusOutOfWindow},
+	ServiceStatusStarting:     {ServiceStatusRunning, ServiceStatusStopped, ServiceStatusOutOfWindow},
+	ServiceStatusRunning:      {ServiceStatusStopping, ServiceStatusExited, ServiceStatusCompleted},
+	ServiceStatusStopping:     {ServiceStatusStopped, ServiceStatusOutOfWindow},
+	ServiceStatusExited:       {ServiceStatusScheduledForRestart, ServiceStatusExhausted},
+
+	// a service which is waiting for the backoff can be stopped.
+	ServiceStatusScheduledForRestart: {ServiceStatusStopped},
+}
+
+func init() {
+	// every idle status can be (re-)started, or held back by its conditions or active windows.
+	// It can also be reset to the registered status, when the runner is booted up again.
+	for _, from := range idle {
+		transitions[from] = append(transitions[from],
+			ServiceStatusRegistered,
+			ServiceStatusPendingStart,
+			ServiceStatusConditionUnmet,
+			ServiceStatusOutOfWindow,
+		)
+	}
+}
+
+// legal returns true if the transition between the given statuses is allowed.
+func legal(from, to ServiceStatus) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stateMachine holds the status of a service, allows only the legal transitions
+// and keeps a bounded history of the transitions.
+type stateMachine struct {
+	// mu is a mutex to protect the status and the history.
+	mu *sync.Mutex
+
+	// status is the current status of the service.
+	status ServiceStatus
+
+	// history is the list of the recent transitions, oldest first.
+	history []Transition
+
+	// size is the maximum number of transitions kept in the history.
+	size int
+
+	// observer is called after every transition, outside of the lock. nil means no observer.
+	// It must not block, as the transitions are made while holding the locks of the service.
+	observer func(Transition)
+
+	// clock is the source of time for the transitions.
+	clock Clock
+}
+
+// newStateMachine returns a new instance of the state machine, in the registered status.
+func newStateMachine(size int, clock Clock) *stateMachine {
+	return &stateMachine{
+		clock:  clock,
+		mu:     &sync.Mutex{},
+		status: ServiceStatusRegistered,
+		size:   size,
+	}
+}
+
+// Current returns the current status.
+func (m *stateMachine) Current() ServiceStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.status
+}
+
+// History returns a copy of the transition history, oldest first.
+func (m *stateMachine) History() []Transition {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Transition(nil), m.history...)
+}
+
+// transition moves the state machine to the given status.
+// A legal transition to the current status (e.g. a re-check of unmet conditions)
+// is a no-op, and is neither recorded in the history nor observed.
+func (m *stateMachine) transition(to ServiceStatus, reason string) error {
+	t, changed, err := m.apply(to, reason)
+	if err != nil || !changed {
+		return err
+	}
+
+	if m.observer != nil {
+		m.observer(t)
+	}
+
+	return nil
+}
+
+// apply makes the transition under the lock.
+// It returns the transition along with a flag to indicate if the status has changed.
+func (m *stateMachine) apply(to ServiceStatus, reason string) (Transition, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	from := m.status
+
+	if !legal(from, to) {
+		return Transition{}, false, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+	}
+
+	if from == to {
+		return Transition{}, false, nil
+	}
+
+	m.status = to
+
+	t := Transition{
+		From:   from,
+		To:     to,
+		Time:   m.clock.Now(),
+		Reason: reason,
+	}
+
+	if m.size <= 0 {
+		return t, true, nil
+	}
+
+	m.history = append(m.history, t)
+
+	if len(m.history) > m.size {
+		m.history = m.history[len(m.history)-m.size:]
+	}
+
+	return t, true, nil
+}
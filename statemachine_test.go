@@ -0,0 +1,137 @@
+package glcm
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateMachineTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    []ServiceStatus
+		wantErr bool
+	}{
+		{
+			name:    "Start and exit",
+			path:    []ServiceStatus{ServiceStatusPendingStart, ServiceStatusStarting, ServiceStatusRunning, ServiceStatusExited},
+			wantErr: false,
+		},
+		{
+			name: "Stop while running",
+			path: []ServiceStatus{
+				ServiceStatusPendingStart, ServiceStatusStarting, ServiceStatusRunning,
+				ServiceStatusStopping, ServiceStatusStopped, ServiceStatusPendingStart,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Restart with backoff",
+			path: []ServiceStatus{
+				ServiceStatusPendingStart, ServiceStatusStarting, ServiceStatusRunning,
+				ServiceStatusExited, ServiceStatusScheduledForRestart, ServiceStatusPendingStart,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Condition unmet",
+			path:    []ServiceStatus{ServiceStatusConditionUnmet, ServiceStatusConditionUnmet, ServiceStatusPendingStart},
+			wantErr: false,
+		},
+		{
+			name: "Reset after stop",
+			path: []ServiceStatus{
+				ServiceStatusPendingStart, ServiceStatusStarting, ServiceStatusRunning,
+				ServiceStatusStopping, ServiceStatusStopped, ServiceStatusRegistered,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Reset while running",
+			path:    []ServiceStatus{ServiceStatusPendingStart, ServiceStatusStarting, ServiceStatusRunning, ServiceStatusRegistered},
+			wantErr: true,
+		},
+		{
+			name:    "Running without starting",
+			path:    []ServiceStatus{ServiceStatusRunning},
+			wantErr: true,
+		},
+		{
+			name:    "Exhausted while running",
+			path:    []ServiceStatus{ServiceStatusPendingStart, ServiceStatusStarting, ServiceStatusRunning, ServiceStatusExhausted},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newStateMachine(10, realClock{})
+
+			var err error
+
+			for _, to := range tt.path {
+				if err = m.transition(to, "test"); err != nil {
+					break
+				}
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("transition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				assert.ErrorIs(t, err, ErrInvalidTransition)
+			}
+		})
+	}
+}
+
+func TestStateMachineHistory(t *testing.T) {
+	m := newStateMachine(2, realClock{})
+
+	assert.Nil(t, m.transition(ServiceStatusPendingStart, "start requested"))
+	assert.Nil(t, m.transition(ServiceStatusStarting, "executing pre-hooks"))
+	assert.Nil(t, m.transition(ServiceStatusRunning, "service started"))
+
+	// a transition to the current status is only allowed for the re-checks.
+	assert.NotNil(t, m.transition(ServiceStatusRunning, "service started"))
+
+	history := m.History()
+
+	assert.Len(t, history, 2, "Expected the history to be bounded")
+	assert.Equal(t, ServiceStatusPendingStart, history[0].From)
+	assert.Equal(t, ServiceStatusStarting, history[0].To)
+	assert.Equal(t, ServiceStatusRunning, history[1].To)
+	assert.Equal(t, "service started", history[1].Reason)
+	assert.Equal(t, ServiceStatusRunning, m.Current())
+}
+
+func TestStateMachineConcurrentTransitions(t *testing.T) {
+	m := newStateMachine(10, realClock{})
+	wg := &sync.WaitGroup{}
+
+	var (
+		mu      sync.Mutex
+		success int
+	)
+
+	// only one of the concurrent starts can claim the service.
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if m.transition(ServiceStatusPendingStart, "start requested") == nil {
+				mu.Lock()
+				success++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, success, "Expected only one start to succeed")
+}
@@ -0,0 +1,97 @@
+package glcm
+
+import (
+	"time"
+)
+
+// runStats keeps the counters and the timings of the runs of a service, since it is registered
+// or the runner is booted up again. It is protected by the lock of the service wrapper.
+type runStats struct {
+	// since is the time from which the downtime and the availability are computed.
+	since time.Time
+
+	// starts is the number of times the service is started.
+	starts int
+
+	// stops is the number of times the service has stopped running, for any reason.
+	stops int
+
+	// lastStart is the time at which the service is last started.
+	lastStart time.Time
+
+	// lastExit is the time at which the service has last stopped running.
+	lastExit time.Time
+
+	// lastExitReason is the reason for which the service has last stopped running.
+	lastExitReason string
+
+	// lastError is the error of the last run, empty if the last run has not failed.
+	lastError string
+
+	// uptime is the cumulative running time of the finished runs.
+	uptime time.Duration
+}
+
+// reset clears the stats and starts counting from the given time.
+func (s *runStats) reset(now time.Time) {
+	*s = runStats{since: now}
+}
+
+// started records a start of the service.
+func (s *runStats) started(now time.Time) {
+	s.starts++
+	s.lastStart = now
+}
+
+// exited records the end of a run of the service.
+func (s *runStats) exited(now time.Time, reason string, err error) {
+	s.stops++
+	s.lastExit = now
+	s.lastExitReason = reason
+	s.lastError = ""
+
+	if err != nil {
+		s.lastError = err.Error()
+	}
+
+	if !s.lastStart.IsZero() {
+		s.uptime += now.Sub(s.lastStart)
+	}
+}
+
+// snapshot returns the stats as of the given time. The current run is counted in the uptime, if the service is running.
+func (s *runStats) snapshot(now time.Time, running bool) ServiceStats {
+	st := ServiceStats{
+		Starts:         s.starts,
+		Stops:          s.stops,
+		LastExitReason: s.lastExitReason,
+		LastError:      s.lastError,
+		TotalUptime:    s.uptime,
+	}
+
+	if !s.lastStart.IsZero() {
+		t := s.lastStart
+		st.LastStart = &t
+	}
+
+	if !s.lastExit.IsZero() {
+		t := s.lastExit
+		st.LastExit = &t
+	}
+
+	if running && !s.lastStart.IsZero() {
+		st.TotalUptime += now.Sub(s.lastStart)
+	}
+
+	elapsed := now.Sub(s.since)
+	if elapsed <= 0 {
+		return st
+	}
+
+	// the uptime can only exceed the elapsed time, if the clock has moved backwards.
+	st.TotalUptime = min(st.TotalUptime, elapsed)
+	st.Downtime = elapsed - st.TotalUptime
+	st.Availability = float64(st.TotalUptime) / float64(elapsed) * 100
+
+	return st
+}
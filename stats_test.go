@@ -0,0 +1,110 @@
+package glcm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunStatsSnapshot(t *testing.T) {
+	boot := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		record  func(s *runStats)
+		now     time.Time
+		running bool
+		want    ServiceStats
+	}{
+		{
+			name: "Never started",
+			now:  boot.Add(time.Minute),
+			want: ServiceStats{Downtime: time.Minute},
+		},
+		{
+			name: "Running since boot",
+			record: func(s *runStats) {
+				s.started(boot)
+			},
+			now:     boot.Add(time.Minute),
+			running: true,
+			want: ServiceStats{
+				Starts:       1,
+				LastStart:    &boot,
+				TotalUptime:  time.Minute,
+				Availability: 100,
+			},
+		},
+		{
+			name: "Failed run followed by a restart",
+			record: func(s *runStats) {
+				s.started(boot)
+				s.exited(boot.Add(time.Minute), "run failed: disk full", errors.New("disk full"))
+				s.started(boot.Add(time.Minute * 2))
+			},
+			now:     boot.Add(time.Minute * 4),
+			running: true,
+			want: ServiceStats{
+				Starts:         2,
+				Stops:          1,
+				LastStart:      ptr(boot.Add(time.Minute * 2)),
+				LastExit:       ptr(boot.Add(time.Minute)),
+				LastExitReason: "run failed: disk full",
+				LastError:      "disk full",
+				TotalUptime:    time.Minute * 3,
+				Downtime:       time.Minute,
+				Availability:   75,
+			},
+		},
+		{
+			name: "Stopped without an error",
+			record: func(s *runStats) {
+				s.started(boot)
+				s.exited(boot.Add(time.Minute), "run failed: disk full", errors.New("disk full"))
+				s.started(boot.Add(time.Minute))
+				s.exited(boot.Add(time.Minute*2), "stopped by the runner", nil)
+			},
+			now: boot.Add(time.Minute * 4),
+			want: ServiceStats{
+				Starts:         2,
+				Stops:          2,
+				LastStart:      ptr(boot.Add(time.Minute)),
+				LastExit:       ptr(boot.Add(time.Minute * 2)),
+				LastExitReason: "stopped by the runner",
+				TotalUptime:    time.Minute * 2,
+				Downtime:       time.Minute * 2,
+				Availability:   50,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &runStats{since: boot}
+
+			if tt.record != nil {
+				tt.record(s)
+			}
+
+			assert.Equal(t, tt.want, s.snapshot(tt.now, tt.running), "Unexpected stats")
+		})
+	}
+}
+
+func TestRunStatsReset(t *testing.T) {
+	boot := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	s := &runStats{since: boot}
+	s.started(boot)
+	s.exited(boot.Add(time.Minute), "exited on its own", nil)
+
+	s.reset(boot.Add(time.Hour))
+
+	assert.Equal(t, ServiceStats{Downtime: time.Minute}, s.snapshot(boot.Add(time.Hour+time.Minute), false), "Expected the stats to be counted from the reset")
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -6,10 +6,31 @@ type ServiceStatus string
 // Status options for the service.
 const (
 	ServiceStatusRegistered          ServiceStatus = "registered"
+	ServiceStatusPendingStart        ServiceStatus = "pending-start"
+	ServiceStatusConditionUnmet      ServiceStatus = "condition-unmet"
+	ServiceStatusOutOfWindow         ServiceStatus = "out-of-window"
+	ServiceStatusStarting            ServiceStatus = "starting"
 	ServiceStatusRunning             ServiceStatus = "running"
+	ServiceStatusStopping            ServiceStatus = "stopping"
 	ServiceStatusExited              ServiceStatus = "exited"
 	ServiceStatusStopped             ServiceStatus = "stopped"
 	ServiceStatusScheduled           ServiceStatus = "scheduled"
 	ServiceStatusScheduledForRestart ServiceStatus = "scheduled-for-restart"
 	ServiceStatusExhausted           ServiceStatus = "exhausted"
+	ServiceStatusCompleted           ServiceStatus = "completed"
 )
+
+// active returns true if the service is running, or is on its way to start or stop.
+func (s ServiceStatus) active() bool {
+	switch s {
+	case ServiceStatusPendingStart, ServiceStatusStarting, ServiceStatusRunning, ServiceStatusStopping:
+		return true
+	default:
+		return false
+	}
+}
+
+// stoppable returns true if the service is active, or is waiting for its restart.
+func (s ServiceStatus) stoppable() bool {
+	return s.active() || s == ServiceStatusScheduledForRestart
+}
@@ -0,0 +1,57 @@
+package glcm
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// regexPrefix is the prefix of the targets, which are regular expressions for the service names.
+const regexPrefix = "re:"
+
+// serviceMatcher returns true if the service with the given name and labels is matched by a target.
+type serviceMatcher func(name string, labels map[string]string) bool
+
+// isGlob returns true if the target of a control operation is a glob pattern for the service names.
+func isGlob(target string) bool {
+	return strings.ContainsAny(target, "*?[")
+}
+
+// parseTarget returns the matcher for a target of a control operation, which may match any number of services.
+// i.e. a regular expression (re:^ingest\.shard-[0-9]+$), a label selector (tier=ingest) or a glob pattern (ingest.*).
+// It returns nil for a target which is a plain service name.
+func parseTarget(target string) (serviceMatcher, error) {
+	switch {
+	case strings.HasPrefix(target, regexPrefix):
+		re, err := regexp.Compile(strings.TrimPrefix(target, regexPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPattern, err)
+		}
+
+		return func(name string, _ map[string]string) bool {
+			return re.MatchString(name)
+		}, nil
+	case isSelector(target):
+		sel, err := ParseSelector(target)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(_ string, labels map[string]string) bool {
+			return sel.Matches(labels)
+		}, nil
+	case isGlob(target):
+		if _, err := path.Match(target, ""); err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidPattern, target, err)
+		}
+
+		return func(name string, _ map[string]string) bool {
+			ok, _ := path.Match(target, name)
+
+			return ok
+		}, nil
+	}
+
+	return nil, nil
+}
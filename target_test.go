@@ -0,0 +1,92 @@
+package glcm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTarget(t *testing.T) {
+	labels := map[string]string{"tier": "ingest"}
+
+	tests := []struct {
+		name    string
+		target  string
+		matches []string
+		misses  []string
+		plain   bool
+		wantErr error
+	}{
+		{
+			name:   "Plain name",
+			target: "ingest.api.shard-1",
+			plain:  true,
+		},
+		{
+			name:    "Glob",
+			target:  "ingest.*",
+			matches: []string{"ingest.api.shard-1", "ingest.worker"},
+			misses:  []string{"billing.api", "ingest"},
+		},
+		{
+			name:    "Glob with character class",
+			target:  "ingest.api.shard-[0-2]",
+			matches: []string{"ingest.api.shard-1"},
+			misses:  []string{"ingest.api.shard-3"},
+		},
+		{
+			name:    "Regex",
+			target:  `re:^ingest\.api\.shard-[0-9]+$`,
+			matches: []string{"ingest.api.shard-1", "ingest.api.shard-12"},
+			misses:  []string{"ingest.api.shard-x", "old.ingest.api.shard-1"},
+		},
+		{
+			name:    "Selector",
+			target:  "tier=ingest",
+			matches: []string{"billing.api"},
+		},
+		{
+			name:    "Invalid regex",
+			target:  "re:ingest.(",
+			wantErr: ErrInvalidPattern,
+		},
+		{
+			name:    "Invalid glob",
+			target:  "ingest.[",
+			wantErr: ErrInvalidPattern,
+		},
+		{
+			name:    "Invalid selector",
+			target:  "tier=a=b",
+			wantErr: ErrInvalidSelector,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := parseTarget(tt.target)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr, "Expected error for invalid target")
+
+				return
+			}
+
+			assert.Nil(t, err, "Expected no error for valid target")
+
+			if tt.plain {
+				assert.Nil(t, match, "Expected no matcher for a plain name")
+
+				return
+			}
+
+			for _, name := range tt.matches {
+				assert.True(t, match(name, labels), "Expected %s to be matched", name)
+			}
+
+			for _, name := range tt.misses {
+				assert.False(t, match(name, nil), "Expected %s to not be matched", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package glcm
+
+import (
+	"sort"
+	"time"
+)
+
+// ActiveWindow represents a recurring time window in which a service may run.
+type ActiveWindow struct {
+	// Days are the week days on which the window opens. Empty means every day.
+	Days []time.Weekday
+
+	// Start is the time of the day (offset from midnight) at which the window opens.
+	Start time.Duration
+
+	// End is the time of the day (offset from midnight) at which the window closes.
+	// If End is not after Start, the window closes on the next day.
+	End time.Duration
+
+	// Location is the time zone of the window. Defaults to UTC.
+	Location *time.Location
+}
+
+// interval represents a single occurrence of an active window.
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// opensOn returns true if the window opens on the given week day.
+func (a ActiveWindow) opensOn(d time.Weekday) bool {
+	if len(a.Days) == 0 {
+		return true
+	}
+
+	for _, day := range a.Days {
+		if day == d {
+			return true
+		}
+	}
+
+	return false
+}
+
+// occurrences returns the occurrences of the window from the day before t till a week after t.
+func (a ActiveWindow) occurrences(t time.Time) []interval {
+	loc := a.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	t = t.In(loc)
+
+	length := a.End - a.Start
+	if length <= 0 {
+		length += time.Hour * 24
+	}
+
+	var res []interval
+
+	for i := -1; i <= 7; i++ {
+		day := time.Date(t.Year(), t.Month(), t.Day()+i, 0, 0, 0, 0, loc)
+		if !a.opensOn(day.Weekday()) {
+			continue
+		}
+
+		start := day.Add(a.Start)
+
+		res = append(res, interval{start: start, end: start.Add(length)})
+	}
+
+	return res
+}
+
+// windowState returns true if t is with in any of the given windows, along with
+// the time of the next transition (close if open, otherwise open).
+// A zero time is returned if there is no transition in the next week.
+func windowState(windows []ActiveWindow, t time.Time) (bool, time.Time) {
+	var all []interval
+
+	for _, w := range windows {
+		all = append(all, w.occurrences(t)...)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].start.Before(all[j].start)
+	})
+
+	// merge the overlapping occurrences, so that the transition is the real close time.
+	var merged []interval
+
+	for _, in := range all {
+		if n := len(merged); n > 0 && !in.start.After(merged[n-1].end) {
+			if in.end.After(merged[n-1].end) {
+				merged[n-1].end = in.end
+			}
+
+			continue
+		}
+
+		merged = append(merged, in)
+	}
+
+	for _, in := range merged {
+		if !t.Before(in.start) && t.Before(in.end) {
+			return true, in.end
+		}
+
+		if in.start.After(t) {
+			return false, in.start
+		}
+	}
+
+	return false, time.Time{}
+}
@@ -0,0 +1,78 @@
+package glcm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindowState(t *testing.T) {
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+	// 2024-01-01 is a Monday.
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		windows  []ActiveWindow
+		at       time.Time
+		wantOpen bool
+		wantNext time.Time
+	}{
+		{
+			name:     "Inside weekday window",
+			windows:  []ActiveWindow{{Days: weekdays, Start: time.Hour, End: time.Hour * 5}},
+			at:       monday.Add(time.Hour * 2),
+			wantOpen: true,
+			wantNext: monday.Add(time.Hour * 5),
+		},
+		{
+			name:     "Before weekday window",
+			windows:  []ActiveWindow{{Days: weekdays, Start: time.Hour, End: time.Hour * 5}},
+			at:       monday.Add(time.Minute * 30),
+			wantOpen: false,
+			wantNext: monday.Add(time.Hour),
+		},
+		{
+			name:     "After friday window",
+			windows:  []ActiveWindow{{Days: weekdays, Start: time.Hour, End: time.Hour * 5}},
+			at:       monday.AddDate(0, 0, 4).Add(time.Hour * 6),
+			wantOpen: false,
+			wantNext: monday.AddDate(0, 0, 7).Add(time.Hour),
+		},
+		{
+			name:     "Window crossing midnight",
+			windows:  []ActiveWindow{{Start: time.Hour * 22, End: time.Hour * 2}},
+			at:       monday.Add(time.Hour),
+			wantOpen: true,
+			wantNext: monday.Add(time.Hour * 2),
+		},
+		{
+			name: "Overlapping windows",
+			windows: []ActiveWindow{
+				{Start: time.Hour, End: time.Hour * 3},
+				{Start: time.Hour * 2, End: time.Hour * 4},
+			},
+			at:       monday.Add(time.Hour * 2),
+			wantOpen: true,
+			wantNext: monday.Add(time.Hour * 4),
+		},
+		{
+			name:     "Window in other location",
+			windows:  []ActiveWindow{{Start: time.Hour, End: time.Hour * 5, Location: time.FixedZone("UTC+2", 2*60*60)}},
+			at:       monday.Add(-time.Minute * 30),
+			wantOpen: true,
+			wantNext: monday.Add(time.Hour * 3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, next := windowState(tt.windows, tt.at)
+
+			assert.Equal(t, tt.wantOpen, open, "Unexpected window state")
+			assert.True(t, tt.wantNext.Equal(next), "Expected next transition %v, got %v", tt.wantNext, next)
+		})
+	}
+}
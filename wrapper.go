@@ -1,6 +1,11 @@
 package glcm
 
 import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"runtime/debug"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -31,11 +36,18 @@ type wrapper struct {
 	// wg is the service wait group. Not the same as the base runner wait group.
 	wg *sync.WaitGroup
 
+	// mu is a mutex to protect the run related fields of the wrapper (channels, times and results).
+	// The status transitions which depend on them are made while holding the mutex.
+	mu *sync.Mutex
+
 	// shutdownRequest is a flag to indicate if the service is requested to stop by the runner.
 	shutdownRequest atomic.Bool
 
-	// status is the current status of the service.
-	status ServiceStatus
+	// checking is a flag to indicate if a start attempt is evaluating the windows and conditions.
+	checking atomic.Bool
+
+	// state holds the current status of the service, along with the transition history.
+	state *stateMachine
 
 	// startTime is the time when the service is started.
 	startTime time.Time
@@ -43,54 +55,188 @@ type wrapper struct {
 	// uptime is the time for which the service has been running.
 	uptime time.Duration
 
+	// oneshot is a flag to indicate if the service runs to completion.
+	oneshot bool
+
+	// result is the result value of the last successful run of a oneshot service.
+	result interface{}
+
+	// runErr is the error reported by the last run of a oneshot service.
+	runErr error
+
+	// periodic drives the periodic (interval or cron scheduled) runs of the service.
+	// nil if the service is neither periodic nor scheduled.
+	periodic *periodic
+
+	// finished is a flag to indicate if the service has run to completion.
+	// i.e. a successful run of a oneshot service, or the last run of a scheduled service.
+	finished bool
+
 	// autorestart related configuration.
 	autoRestart AutoRestart
 
-	// scheduling related configuration.
+	// conditions are the predicates which must be met before starting the service.
+	conditions []Condition
+
+	// windows are the time windows in which the service may run.
+	windows []ActiveWindow
+
+	// startDelay is the delay before the service is started for the first time.
+	startDelay time.Duration
+
+	// gate limits the concurrent starts across the runner. nil means no limit.
+	gate *startGate
+
+	// clock is the source of time for the service.
+	clock Clock
+
+	// reloadFallback is a flag to indicate if the service is restarted when its reload fails.
+	reloadFallback bool
+
+	// reload is the outcome of the last reload of the service.
+	reload *ReloadResult
+
+	// subs holds the process signals to which the current run of the service has subscribed.
+	subs *signalSubscription
+
+	// onSubscribe is called with the signals subscribed by the service. nil means no callback.
+	onSubscribe func(...os.Signal)
+
+	// critical is a flag to indicate if the runner is shut down, when the service fails for good.
+	critical bool
+
+	// check is the outcome of the last health check of the current run of the service.
+	check *HealthCheckResult
 
-	ScheduleEnabled        bool          // flag to indicate if scheduling is enabled.
-	ScheduleCronExpression string        // cron expression for scheduling the service.
-	ScheduleTimeOut        time.Duration // execution timeout for the service.
-	ScheduleMaxRuns        int           // maximum number of runs for the service.
+	// stats keeps the counters and the timings of the runs of the service.
+	stats runStats
+
+	// meta is the descriptive information of the service.
+	meta ServiceMetadata
 }
 
 // AutoRestart is the configuration set for auto-restart.
 type AutoRestart struct {
-	Enabled         bool        // flag to indicate if auto-restart is enabled.
-	MaxRetries      int         // maximum number of retries.
-	Backoff         bool        // flag to indicate if backoff is enabled.
-	BackoffExponent int         // exponent for the backoff.
-	RetryCount      int         // current number of retries for the service.
-	PendingStart    atomic.Bool // flag to indicate if the service is pending for a start after the backoff.
+	Enabled         bool // flag to indicate if auto-restart is enabled.
+	MaxRetries      int  // maximum number of retries.
+	Backoff         bool // flag to indicate if backoff is enabled.
+	BackoffExponent int  // exponent for the backoff.
+	RetryCount      int  // current number of retries for the service.
+
+	// PendingStart represents if the service is pending for a start, e.g. after the backoff.
+	//
+	// Deprecated: use the status of the service (ServiceStatusScheduledForRestart or ServiceStatusPendingStart).
+	// It is only filled in the copy returned by Wrapper.AutoRestart, storing to it has no effect.
+	PendingStart atomic.Bool
 }
 
 // NewWrapper returns a new instance of the service Wrapper.
 func NewWrapper(s Service, wg *sync.WaitGroup, opts ServiceOptions) Wrapper {
+	return newWrapper(s, wg, opts, realClock{})
+}
+
+// newWrapper returns a new instance of the service wrapper.
+// The runner uses it to set the runner level dependencies on the wrapper.
+func newWrapper(s Service, wg *sync.WaitGroup, opts ServiceOptions, clock Clock) *wrapper {
 	w := &wrapper{
 		s:         s,
 		wg:        wg,
 		preHooks:  opts.PreHooks,
 		postHooks: opts.PostHooks,
-		status:    ServiceStatusRegistered,
+		mu:        &sync.Mutex{},
+		state:     newStateMachine(opts.HistorySize, clock),
+		oneshot:   opts.Type == ServiceTypeOneshot,
+		periodic:  newPeriodic(opts.Every, clock),
 		autoRestart: AutoRestart{
 			RetryCount:      0,
 			Enabled:         opts.AutoStart.Enabled,
 			MaxRetries:      opts.AutoStart.MaxRetries,
 			Backoff:         opts.AutoStart.Backoff,
 			BackoffExponent: opts.AutoStart.BackOffExponent,
-			PendingStart:    atomic.Bool{},
 		},
-		ScheduleEnabled:        opts.Schedule.Enabled,
-		ScheduleCronExpression: opts.Schedule.Cron,
-		ScheduleTimeOut:        opts.Schedule.TimeOut,
-		ScheduleMaxRuns:        opts.Schedule.MaxRuns,
+		conditions: opts.Conditions,
+		windows:    opts.ActiveWindows,
+		startDelay: opts.StartDelay,
+		clock:      clock,
+
+		reloadFallback: opts.ReloadFallback,
+		subs:           newSignalSubscription(),
+		critical:       opts.Critical,
+		stats:          runStats{since: clock.Now()},
+		meta: ServiceMetadata{
+			Description: opts.Description,
+			Owner:       opts.Owner,
+			Version:     opts.Version,
+			Labels:      maps.Clone(opts.Labels),
+		},
+	}
+
+	if w.periodic == nil {
+		sched, err := newScheduled(opts.Schedule, clock)
+		if err != nil {
+			log.Errorf("Service %s is not scheduled: %v", s.Name(), err)
+		}
+
+		w.periodic = sched
+	}
+
+	// the runs of a periodic service subscribe to the signals of the service.
+	if w.periodic != nil {
+		w.periodic.subscribe = w.Signals
 	}
 
 	return w
 }
 
+// AutoRestart returns a copy of the auto-restart configuration, along with the current number of retries.
+// Changing the copy does not change the service, use IncRetry and Reset for the retry count.
 func (w *wrapper) AutoRestart() *AutoRestart {
-	return &w.autoRestart
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ar := &AutoRestart{
+		Enabled:         w.autoRestart.Enabled,
+		MaxRetries:      w.autoRestart.MaxRetries,
+		Backoff:         w.autoRestart.Backoff,
+		BackoffExponent: w.autoRestart.BackoffExponent,
+		RetryCount:      w.autoRestart.RetryCount,
+	}
+
+	status := w.state.Current()
+	ar.PendingStart.Store(status == ServiceStatusScheduledForRestart || status == ServiceStatusPendingStart)
+
+	return ar
+}
+
+// Retries returns the current number of retries for the service.
+func (w *wrapper) Retries() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.autoRestart.RetryCount
+}
+
+// IncRetry increments the number of retries for the service and returns the new count.
+func (w *wrapper) IncRetry() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.autoRestart.RetryCount++
+
+	return w.autoRestart.RetryCount
+}
+
+// Critical returns true if the runner is shut down, when the service fails for good.
+func (w *wrapper) Critical() bool {
+	return w.critical
+}
+
+// Metadata returns the descriptive information of the service.
+func (w *wrapper) Metadata() ServiceMetadata {
+	meta := w.meta
+	meta.Labels = maps.Clone(w.meta.Labels)
+
+	return meta
 }
 
 func (w *wrapper) Name() string {
@@ -98,72 +244,313 @@ func (w *wrapper) Name() string {
 }
 
 func (w *wrapper) Status() ServiceStatus {
-	return w.status
+	return w.state.Current()
+}
+
+// History returns the recent status transitions of the service, oldest first.
+func (w *wrapper) History() []Transition {
+	return w.state.History()
+}
+
+// Transition moves the service to the given status, if the transition is legal.
+func (w *wrapper) Transition(to ServiceStatus, reason string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.state.transition(to, reason)
 }
 
 func (w *wrapper) Uptime() time.Duration {
-	if w.status == ServiceStatusRunning {
-		return time.Since(w.startTime)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.state.Current() == ServiceStatusRunning {
+		return w.clock.Since(w.startTime)
 	}
 
 	return w.uptime
 }
 
+// Stats returns the counters and the timings of the runs of the service,
+// since the service is registered or the runner is booted up again.
+func (w *wrapper) Stats() ServiceStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	st := w.state.Current()
+
+	return w.stats.snapshot(w.clock.Now(), st == ServiceStatusRunning || st == ServiceStatusStopping)
+}
+
+// Result returns the result value of the last successful run of a oneshot service.
+func (w *wrapper) Result() interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.result
+}
+
+// Details returns the custom details reported by the service, if the service implements the StatusReporter interface.
+// A panic while reporting the details is recovered, so that the status of the other services is still available.
+func (w *wrapper) Details() (details map[string]interface{}) {
+	sr, ok := w.s.(StatusReporter)
+	if !ok {
+		return nil
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			log.Errorf("Service %s panicked while reporting the status details: %v", w.s.Name(), p)
+
+			details = nil
+		}
+	}()
+
+	return sr.StatusDetails()
+}
+
+// Runs returns the run statistics of a periodic service. nil if the service is not periodic.
+func (w *wrapper) Runs() *RunStats {
+	return w.periodic.Stats()
+}
+
+// InActiveWindow returns true if the service is with in one of its active windows.
+func (w *wrapper) InActiveWindow() bool {
+	if len(w.windows) == 0 {
+		return true
+	}
+
+	open, _ := windowState(w.windows, w.clock.Now())
+
+	return open
+}
+
+// NextTransition returns the time of the next active window transition of the service.
+func (w *wrapper) NextTransition() time.Time {
+	if len(w.windows) == 0 {
+		return time.Time{}
+	}
+
+	_, next := windowState(w.windows, w.clock.Now())
+
+	return next
+}
+
 // Done marks the services as done in the workergroup and closes the indication channel.
 func (w *wrapper) done() {
+	w.mu.Lock()
+
+	// Record the uptime, only if the service was started.
+	started := false
+
+	if st := w.state.Current(); st == ServiceStatusRunning || st == ServiceStatusStopping {
+		w.uptime = w.clock.Since(w.startTime)
+		started = true
+	}
+
 	// indicate whether the service has stopped by runner or exited on its own.
 	// if the service is stopped by the runner (shudownRequest will be set to true), then the status will be stopped.
 	// if the service has exited on its own, then the status will be exited.
-	if w.shutdownRequest.Load() {
-		w.status = ServiceStatusStopped
-	} else {
-		w.status = ServiceStatusExited
+	// if the service is stopped as its active window has closed, then the status will be out-of-window.
+	// if the service has run to completion, then the status will be completed.
+	var (
+		to     ServiceStatus
+		reason string
+	)
+
+	switch {
+	case w.shutdownRequest.Load() && !w.InActiveWindow():
+		to, reason = ServiceStatusOutOfWindow, "active window closed"
+	case w.shutdownRequest.Load():
+		to, reason = ServiceStatusStopped, "stopped by the runner"
+	case w.finished:
+		to, reason = ServiceStatusCompleted, "run to completion"
+	case w.runErr != nil:
+		to, reason = ServiceStatusExited, fmt.Sprintf("run failed: %v", w.runErr)
+	default:
+		to, reason = ServiceStatusExited, "exited on its own"
+	}
+
+	if started {
+		var runErr error
+		if to == ServiceStatusExited {
+			runErr = w.runErr
+		}
+
+		w.stats.exited(w.clock.Now(), reason, runErr)
 	}
 
-	// Record the uptime
-	w.uptime = time.Since(w.startTime)
+	if err := w.state.transition(to, reason); err != nil {
+		log.Errorf("Service %s: %v", w.s.Name(), err)
+	}
 
 	// clearing the shutdown request flag.
 	w.shutdownRequest.Store(false)
 
-	w.wg.Done()
+	dic := w.dic
 
-	close(w.dic)
-}
+	w.mu.Unlock()
 
-// Wait blocks the caller until the service is stopped.
-func (w *wrapper) wait() {
-	<-w.dic
+	w.wg.Done()
+
+	close(dic)
 }
 
 // TermCh returns the termination channel for the service.
 // The service implmentation is expected to listen to this channel and
 // stop the service when it is closed.
 func (w *wrapper) TermCh() chan struct{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
 	return w.tc
 }
 
-// reallocate the chan before starting if it is nil
+// Signals subscribes the current run of the service to the given process signals.
+// The subscription is cleared when the service is started again.
+func (w *wrapper) Signals(sig ...os.Signal) <-chan os.Signal {
+	w.mu.Lock()
+	subs, onSubscribe := w.subs, w.onSubscribe
+	w.mu.Unlock()
+
+	ch := subs.subscribe(sig...)
+
+	if onSubscribe != nil {
+		onSubscribe(sig...)
+	}
+
+	return ch
+}
+
+// Signal delivers the signal to the service, if the service is running and has subscribed to it.
+func (w *wrapper) Signal(sig os.Signal) error {
+	if status := w.Status(); status != ServiceStatusRunning {
+		return fmt.Errorf("%w: %s", ErrServiceNotRunning, status)
+	}
+
+	w.mu.Lock()
+	subs := w.subs
+	w.mu.Unlock()
+
+	return subs.deliver(sig)
+}
+
+// admit evaluates the active windows and the start conditions of the service.
+// It returns false, after moving the service to the respective status, if the service can not be started.
+func (w *wrapper) admit() bool {
+	// only one start attempt evaluates the windows and conditions at a time.
+	if !w.checking.CompareAndSwap(false, true) {
+		return false
+	}
+
+	defer w.checking.Store(false)
+
+	// the service stays in out-of-window status till the next active window opens.
+	if !w.InActiveWindow() {
+		log.Infof("Service %s is not started, outside of its active windows", w.s.Name())
+
+		if err := w.Transition(ServiceStatusOutOfWindow, "outside of the active windows"); err != nil {
+			log.Infof("Service %s: %v", w.s.Name(), err)
+		}
+
+		return false
+	}
+
+	// the service stays in condition-unmet status till the runner re-checks it.
+	if err := w.checkConditions(); err != nil {
+		log.Infof("Service %s is not started, %v", w.s.Name(), err)
+
+		if err := w.Transition(ServiceStatusConditionUnmet, err.Error()); err != nil {
+			log.Infof("Service %s: %v", w.s.Name(), err)
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// Start starts the service and blocks till the service exits.
+// The channels are reallocated on every start.
 func (w *wrapper) Start() {
-	if w.status == ServiceStatusRunning {
-		log.Infof("Service %s is already running", w.s.Name())
+	if w.Status().active() {
+		log.Infof("Service %s is already running or pending start", w.s.Name())
+
+		return
+	}
 
+	if !w.admit() {
 		return
 	}
 
+	w.mu.Lock()
+
+	// the transition fails, if the service has been started by a concurrent call.
+	if err := w.state.transition(ServiceStatusPendingStart, "start requested"); err != nil {
+		w.mu.Unlock()
+
+		log.Infof("Service %s is not started: %v", w.s.Name(), err)
+
+		return
+	}
+
+	// the start delay is only applicable for the first start of the service.
+	initial := w.startTime.IsZero()
+
 	// we don't know if this is the first time the service is getting started.
 	// So, we need to reallocate the channels.
 	w.dic = make(chan struct{})
 	w.tc = make(chan struct{})
+	w.subs = newSignalSubscription()
+	w.finished = false
+	w.runErr = nil
+
+	tc := w.tc
+
+	w.mu.Unlock()
 
 	w.wg.Add(1)
 
 	defer func() {
 		w.done() // finalizer for the service wrapper.
 
-		log.Infof("service %s status [%s]", w.s.Name(), w.status)
+		log.Infof("service %s status [%s]", w.s.Name(), w.Status())
+	}()
+
+	if initial && w.startDelay > 0 {
+		log.Infof("Delaying the start of service %s by %s ...", w.s.Name(), w.startDelay)
+
+		select {
+		case <-w.clock.After(w.startDelay):
+		case <-tc:
+			log.Infof("Service %s stopped while waiting for the start delay", w.s.Name())
+
+			return
+		}
+	}
+
+	// wait for a start slot, the service stays in pending-start status till then.
+	if !w.gate.acquire(tc) {
+		log.Infof("Service %s stopped while waiting for a start slot", w.s.Name())
+
+		return
+	}
+
+	// the start slot is held till the service is running, or the start is aborted.
+	released := false
+
+	defer func() {
+		if !released {
+			w.gate.release()
+		}
 	}()
 
+	if err := w.Transition(ServiceStatusStarting, "executing pre-hooks"); err != nil {
+		log.Errorf("Service %s: %v", w.s.Name(), err)
+
+		return
+	}
+
 	// call the pre exec hooks
 	func() {
 		log.Infof("Executing pre-hooks for service %s ...", w.s.Name())
@@ -178,13 +565,44 @@ func (w *wrapper) Start() {
 		}
 	}()
 
+	w.mu.Lock()
+
+	// the service might have been stopped while executing the pre-hooks.
+	if w.shutdownRequest.Load() {
+		w.mu.Unlock()
+
+		log.Infof("Service %s stopped while executing the pre-hooks", w.s.Name())
+
+		return
+	}
+
 	// start the service
 	log.Infof("starting service %s ...", w.s.Name())
 
-	w.startTime = time.Now()
-	w.status = ServiceStatusRunning
-	w.autoRestart.PendingStart.Store(false)
-	w.s.Start(w)
+	w.startTime = w.clock.Now()
+	w.check = nil
+	w.stats.started(w.startTime)
+
+	if err := w.state.transition(ServiceStatusRunning, "service started"); err != nil {
+		log.Errorf("Service %s: %v", w.s.Name(), err)
+	}
+
+	w.mu.Unlock()
+
+	w.gate.release()
+
+	released = true
+
+	// a periodic service is running till it is stopped, each run calls the Start of the service.
+	if w.periodic != nil {
+		finished := w.periodic.loop(w.s, tc)
+
+		w.mu.Lock()
+		w.finished = finished
+		w.mu.Unlock()
+	} else {
+		w.startService()
+	}
 
 	// call the post exec hooks.
 	// Note: we don't really need the ignore flag here,,
@@ -203,19 +621,241 @@ func (w *wrapper) Start() {
 	}()
 }
 
+// startService calls the Start of the service, and collects the result of the run.
+// The panic of a critical service is recovered as a failed run, so that the runner can shut down gracefully.
+func (w *wrapper) startService() {
+	if w.critical {
+		defer func() {
+			if p := recover(); p != nil {
+				log.Errorf("Critical service %s panicked: %v\n%s", w.s.Name(), p, debug.Stack())
+
+				w.mu.Lock()
+				w.runErr = fmt.Errorf("panic: %v", p)
+				w.mu.Unlock()
+			}
+		}()
+	}
+
+	w.s.Start(w)
+	w.collectResult()
+}
+
+// collectResult records the outcome of the run for the oneshot services.
+func (w *wrapper) collectResult() {
+	if !w.oneshot {
+		return
+	}
+
+	var (
+		res interface{}
+		err error
+	)
+
+	if job, ok := w.s.(Job); ok {
+		res, err = job.Result()
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err != nil {
+		log.Errorf("Service %s run failed: %v", w.s.Name(), err)
+
+		w.runErr = err
+
+		return
+	}
+
+	w.result = res
+	w.finished = true
+}
+
+// checkConditions evaluates the start conditions of the service.
+// It returns an error for the first condition which is not met.
+func (w *wrapper) checkConditions() error {
+	for _, c := range w.conditions {
+		if err := c.Check(); err != nil {
+			return fmt.Errorf("condition %s not met: %w", c.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// Reset moves an idle service back to the registered status and clears its retry count and run statistics,
+// so that it is started (after its start delay) as on the first boot up of the runner.
+// It fails for a service which is still active.
+func (w *wrapper) Reset() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.state.transition(ServiceStatusRegistered, "reset for boot up"); err != nil {
+		return err
+	}
+
+	w.autoRestart.RetryCount = 0
+	w.startTime = time.Time{}
+	w.check = nil
+	w.stats.reset(w.clock.Now())
+	w.periodic.reset()
+
+	return nil
+}
+
+// Reload reloads the running service, if the service implements the Reloader interface.
+// A failed (or unsupported) reload is followed by a restart, if the reload fallback is enabled for the service.
+// The outcome is recorded for the status of the service.
+func (w *wrapper) Reload(ctx context.Context) error {
+	if status := w.Status(); status != ServiceStatusRunning {
+		return fmt.Errorf("%w: %s", ErrServiceNotRunning, status)
+	}
+
+	err := ErrReloadNotSupported
+
+	if rl, ok := w.s.(Reloader); ok {
+		log.Infof("Reloading service %s ...", w.s.Name())
+
+		err = rl.Reload(ctx)
+	}
+
+	res := &ReloadResult{Time: w.clock.Now()}
+
+	if err != nil {
+		res.Error = err.Error()
+	}
+
+	if err != nil && w.reloadFallback {
+		log.Warnf("Reload of service %s failed: %v. Restarting service ...", w.s.Name(), err)
+
+		res.Restarted = true
+
+		if rerr := stopWithin(ctx, w); rerr != nil {
+			err = fmt.Errorf("%w, restart: %w", err, rerr)
+			res.Error = err.Error()
+		} else {
+			err = nil
+
+			go w.Start()
+		}
+	}
+
+	w.mu.Lock()
+	w.reload = res
+	w.mu.Unlock()
+
+	return err
+}
+
+// Reloadable returns true if the service implements the Reloader interface.
+func (w *wrapper) Reloadable() bool {
+	_, ok := w.s.(Reloader)
+
+	return ok
+}
+
+// LastReload returns the outcome of the last reload of the service.
+func (w *wrapper) LastReload() *ReloadResult {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.reload == nil {
+		return nil
+	}
+
+	res := *w.reload
+
+	return &res
+}
+
+// CheckHealth runs the health check of the running service, if the service implements the HealthChecker interface.
+// The outcome is recorded for the status of the service, till the service is started again.
+func (w *wrapper) CheckHealth(ctx context.Context) error {
+	if status := w.Status(); status != ServiceStatusRunning {
+		return fmt.Errorf("%w: %s", ErrServiceNotRunning, status)
+	}
+
+	hc, ok := w.s.(HealthChecker)
+	if !ok {
+		return nil
+	}
+
+	err := hc.HealthCheck(ctx)
+
+	res := &HealthCheckResult{Time: w.clock.Now()}
+
+	if err != nil {
+		log.Warnf("Health check of service %s failed: %v", w.s.Name(), err)
+
+		res.Error = err.Error()
+	}
+
+	w.mu.Lock()
+	w.check = res
+	w.mu.Unlock()
+
+	return err
+}
+
+// LastHealthCheck returns the outcome of the last health check of the service.
+func (w *wrapper) LastHealthCheck() *HealthCheckResult {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.check == nil {
+		return nil
+	}
+
+	res := *w.check
+
+	return &res
+}
+
 // Stop stops the service and waits for it to exit.
+// A service which is waiting for a restart after the backoff, is marked as stopped.
 func (w *wrapper) Stop() {
-	if !(w.status == ServiceStatusRunning) {
+	w.mu.Lock()
+
+	status := w.state.Current()
+
+	if status == ServiceStatusScheduledForRestart {
+		if err := w.state.transition(ServiceStatusStopped, "stopped while waiting for the restart"); err != nil {
+			log.Errorf("Service %s: %v", w.s.Name(), err)
+		}
+
+		w.mu.Unlock()
+
+		return
+	}
+
+	if !status.active() {
+		w.mu.Unlock()
+
 		return
 	}
 
-	log.Infof("Stopping service %s ...", w.s.Name())
+	tc, dic := w.tc, w.dic
+
+	// the shutdown request flag is set before closing the termination channel,
+	// so that the service is marked as stopped, not exited.
+	first := w.shutdownRequest.CompareAndSwap(false, true)
+
+	if first && status == ServiceStatusRunning {
+		if err := w.state.transition(ServiceStatusStopping, "stop requested"); err != nil {
+			log.Errorf("Service %s: %v", w.s.Name(), err)
+		}
+	}
+
+	w.mu.Unlock()
 
-	close(w.tc)
+	if first {
+		log.Infof("Stopping service %s ...", w.s.Name())
 
-	w.shutdownRequest.Store(true)
+		close(tc)
+	} else {
+		log.Infof("Service %s is already stopping ...", w.s.Name())
+	}
 
 	log.Infof("Waiting for the service %s to exit ...", w.s.Name())
 
-	w.wait()
+	<-dic
 }
@@ -1,6 +1,9 @@
 package glcm
 
 import (
+	"context"
+	"errors"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -107,3 +110,400 @@ func (m *mockHook) Execute() error {
 func (m *mockHook) Name() string {
 	return m.name
 }
+
+func TestWrapper_StartDelay(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	svc := &mockService{}
+	w := NewWrapper(svc, wg, ServiceOptions{
+		StartDelay: time.Second * 10,
+	})
+
+	go w.Start()
+
+	<-time.After(time.Millisecond * 100)
+
+	if w.Status() != ServiceStatusPendingStart {
+		t.Errorf("Expected service to be pending start, got %s", w.Status())
+	}
+
+	// stopping a service which is pending start should cancel the start.
+	w.Stop()
+
+	if svc.started {
+		t.Errorf("Service was started while pending start")
+	}
+
+	if w.Status() != ServiceStatusStopped {
+		t.Errorf("Expected service to be stopped, got %s", w.Status())
+	}
+}
+
+func TestWrapper_StartGate(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	gate := newStartGate(1, 0, realClock{})
+
+	// occupy the only start slot.
+	gate.acquire(nil)
+
+	svc := &mockService{}
+	w := newWrapper(svc, wg, ServiceOptions{}, realClock{})
+	w.gate = gate
+
+	go w.Start()
+
+	<-time.After(time.Millisecond * 100)
+
+	if w.Status() != ServiceStatusPendingStart {
+		t.Errorf("Expected service to be pending start, got %s", w.Status())
+	}
+
+	gate.release()
+
+	<-time.After(time.Millisecond * 100)
+
+	if w.Status() != ServiceStatusRunning {
+		t.Errorf("Expected service to be running, got %s", w.Status())
+	}
+
+	w.Stop()
+
+	if !svc.stopped {
+		t.Errorf("Service was not stopped")
+	}
+}
+
+func TestWrapper_ConditionUnmet(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	svc := &mockService{}
+
+	met := false
+	w := NewWrapper(svc, wg, ServiceOptions{
+		Conditions: []Condition{
+			NewCondition("flag", func() error {
+				if !met {
+					return errors.New("flag not set")
+				}
+
+				return nil
+			}),
+		},
+	})
+
+	w.Start()
+
+	if svc.started {
+		t.Errorf("Service was started with unmet conditions")
+	}
+
+	if w.Status() != ServiceStatusConditionUnmet {
+		t.Errorf("Expected service to be condition-unmet, got %s", w.Status())
+	}
+
+	met = true
+
+	go w.Start()
+
+	<-time.After(time.Millisecond * 100)
+
+	if w.Status() != ServiceStatusRunning {
+		t.Errorf("Expected service to be running, got %s", w.Status())
+	}
+
+	w.Stop()
+}
+
+func TestWrapper_OutOfWindow(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	svc := &mockService{}
+
+	// a window which opens an hour from now, and lasts for a minute.
+	now := time.Now().UTC()
+	start := now.Add(time.Hour).Sub(now.Truncate(time.Hour * 24))
+
+	w := NewWrapper(svc, wg, ServiceOptions{
+		ActiveWindows: []ActiveWindow{{Start: start, End: start + time.Minute}},
+	})
+
+	if w.InActiveWindow() {
+		t.Errorf("Expected service to be outside of its active window")
+	}
+
+	if w.NextTransition().IsZero() {
+		t.Errorf("Expected next transition to be set")
+	}
+
+	w.Start()
+
+	if svc.started {
+		t.Errorf("Service was started outside of its active window")
+	}
+
+	if w.Status() != ServiceStatusOutOfWindow {
+		t.Errorf("Expected service to be out-of-window, got %s", w.Status())
+	}
+}
+
+func TestWrapper_Oneshot(t *testing.T) {
+	tests := []struct {
+		name       string
+		svc        Service
+		wantStatus ServiceStatus
+		wantResult interface{}
+	}{
+		{
+			name:       "Oneshot without result",
+			svc:        &mockJob{},
+			wantStatus: ServiceStatusCompleted,
+			wantResult: nil,
+		},
+		{
+			name:       "Oneshot with result",
+			svc:        &mockJob{result: 42},
+			wantStatus: ServiceStatusCompleted,
+			wantResult: 42,
+		},
+		{
+			name:       "Oneshot with error",
+			svc:        &mockJob{result: 42, err: errors.New("job failed")},
+			wantStatus: ServiceStatusExited,
+			wantResult: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wg := &sync.WaitGroup{}
+			w := NewWrapper(tt.svc, wg, ServiceOptions{Type: ServiceTypeOneshot})
+
+			w.Start()
+
+			if w.Status() != tt.wantStatus {
+				t.Errorf("Expected service to be %s, got %s", tt.wantStatus, w.Status())
+			}
+
+			if w.Result() != tt.wantResult {
+				t.Errorf("Expected result %v, got %v", tt.wantResult, w.Result())
+			}
+		})
+	}
+}
+
+type mockJob struct {
+	result interface{}
+	err    error
+}
+
+func (m *mockJob) Start(Terminator) {}
+
+func (m *mockJob) Name() string {
+	return "mockJob"
+}
+
+func (m *mockJob) Result() (interface{}, error) {
+	return m.result, m.err
+}
+
+func TestWrapper_Reset(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	svc := &mockService{}
+	w := NewWrapper(svc, wg, ServiceOptions{})
+
+	go w.Start()
+
+	<-time.After(time.Millisecond * 100)
+
+	if err := w.Reset(); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected error resetting a running service, got %v", err)
+	}
+
+	w.Stop()
+
+	for i := 0; i < 3; i++ {
+		w.IncRetry()
+	}
+
+	if err := w.Reset(); err != nil {
+		t.Errorf("Expected no error resetting a stopped service, got %v", err)
+	}
+
+	if w.Status() != ServiceStatusRegistered {
+		t.Errorf("Expected service to be registered, got %s", w.Status())
+	}
+
+	if w.Retries() != 0 {
+		t.Errorf("Expected the retry count to be cleared, got %d", w.Retries())
+	}
+
+	// a registered service is reset as a no-op.
+	if err := w.Reset(); err != nil {
+		t.Errorf("Expected no error resetting a registered service, got %v", err)
+	}
+}
+
+func TestWrapper_AutoRestart(t *testing.T) {
+	w := NewWrapper(&mockService{}, &sync.WaitGroup{}, ServiceOptions{
+		AutoStart: AutoRestartOptions{Enabled: true, MaxRetries: 3, Backoff: true, BackOffExponent: 2},
+	})
+
+	w.IncRetry()
+
+	ar := w.AutoRestart()
+
+	if !ar.Enabled || ar.MaxRetries != 3 || !ar.Backoff || ar.BackoffExponent != 2 || ar.RetryCount != 1 {
+		t.Errorf("Unexpected auto-restart configuration %+v", ar)
+	}
+
+	if ar.PendingStart.Load() {
+		t.Errorf("Expected the registered service to not be pending start")
+	}
+
+	// the copy does not change the service.
+	ar.RetryCount = 0
+
+	if w.Retries() != 1 {
+		t.Errorf("Expected the retry count to be unchanged, got %d", w.Retries())
+	}
+
+	if err := w.Transition(ServiceStatusPendingStart, "start requested"); err != nil {
+		t.Fatalf("Expected no error for the transition, got %v", err)
+	}
+
+	if !w.AutoRestart().PendingStart.Load() {
+		t.Errorf("Expected the deprecated pending start flag to follow the status")
+	}
+}
+
+// reloadableService is a service which can be reloaded.
+type reloadableService struct {
+	mockService
+
+	err     error
+	reloads int
+}
+
+func (r *reloadableService) Reload(ctx context.Context) error {
+	r.reloads++
+
+	return r.err
+}
+
+func TestWrapper_Reload(t *testing.T) {
+	tests := []struct {
+		name          string
+		svc           Service
+		fallback      bool
+		wantErr       error
+		wantRestarted bool
+	}{
+		{
+			name: "Reload succeeds",
+			svc:  &reloadableService{},
+		},
+		{
+			name:    "Reload fails",
+			svc:     &reloadableService{err: errors.New("bad config")},
+			wantErr: errors.New("bad config"),
+		},
+		{
+			name:          "Reload fails with fallback",
+			svc:           &reloadableService{err: errors.New("bad config")},
+			fallback:      true,
+			wantRestarted: true,
+		},
+		{
+			name:    "Reload not supported",
+			svc:     &mockService{},
+			wantErr: ErrReloadNotSupported,
+		},
+		{
+			name:          "Reload not supported with fallback",
+			svc:           &mockService{},
+			fallback:      true,
+			wantRestarted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wg := &sync.WaitGroup{}
+			w := NewWrapper(tt.svc, wg, ServiceOptions{ReloadFallback: tt.fallback})
+
+			if err := w.Reload(context.Background()); !errors.Is(err, ErrServiceNotRunning) {
+				t.Errorf("Expected error reloading a service which is not running, got %v", err)
+			}
+
+			go w.Start()
+
+			<-time.After(time.Millisecond * 100)
+
+			err := w.Reload(context.Background())
+
+			if (err == nil) != (tt.wantErr == nil) || (err != nil && err.Error() != tt.wantErr.Error()) {
+				t.Errorf("Expected reload error %v, got %v", tt.wantErr, err)
+			}
+
+			res := w.LastReload()
+			if res == nil {
+				t.Fatalf("Expected the reload to be recorded")
+			}
+
+			if res.Restarted != tt.wantRestarted {
+				t.Errorf("Expected restarted %t, got %t", tt.wantRestarted, res.Restarted)
+			}
+
+			<-time.After(time.Millisecond * 100)
+
+			if w.Status() != ServiceStatusRunning {
+				t.Errorf("Expected service to be running after the reload, got %s", w.Status())
+			}
+
+			w.Stop()
+		})
+	}
+}
+
+// reportingService reports the depth of its queue, or panics.
+type reportingService struct {
+	mockService
+	panics bool
+}
+
+func (r *reportingService) StatusDetails() map[string]interface{} {
+	if r.panics {
+		panic("queue is gone")
+	}
+
+	return map[string]interface{}{"queueDepth": 42}
+}
+
+func TestWrapper_Details(t *testing.T) {
+	tests := []struct {
+		name string
+		svc  Service
+		want map[string]interface{}
+	}{
+		{
+			name: "Service reports details",
+			svc:  &reportingService{},
+			want: map[string]interface{}{"queueDepth": 42},
+		},
+		{
+			name: "Service panics while reporting details",
+			svc:  &reportingService{panics: true},
+		},
+		{
+			name: "Service without details",
+			svc:  &mockService{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWrapper(tt.svc, &sync.WaitGroup{}, ServiceOptions{})
+
+			if got := w.Details(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected details %v, got %v", tt.want, got)
+			}
+		})
+	}
+}